@@ -0,0 +1,31 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"Name_IQ_Finder/internal/mockapi"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "listen address")
+	scenarioPath := flag.String("scenario", "", "path to a JSON scenario file")
+	flag.Parse()
+
+	scenario := &mockapi.Scenario{}
+	if *scenarioPath != "" {
+		var err error
+		scenario, err = mockapi.LoadScenario(*scenarioPath)
+		if err != nil {
+			log.Fatalf("Failed to load scenario: %v", err)
+		}
+	}
+
+	server := mockapi.NewServer(scenario)
+
+	log.Printf("Mock provider API listening on %s (agify: /agify/, genderize: /genderize/, nationalize: /nationalize/)", *addr)
+	if err := http.ListenAndServe(*addr, server.Handler()); err != nil {
+		log.Fatalf("Failed to start mock server: %v", err)
+	}
+}
@@ -5,18 +5,27 @@ import (
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	Database DatabaseConfig `yaml:"database"`
-	Logger   LoggerConfig   `yaml:"logger"`
+	Server     ServerConfig     `yaml:"server"`
+	Database   DatabaseConfig   `yaml:"database"`
+	Logger     LoggerConfig     `yaml:"logger"`
+	Cache      CacheConfig      `yaml:"cache"`
+	External   ExternalConfig   `yaml:"external"`
+	Enrichment EnrichmentConfig `yaml:"enrichment"`
+	Refresh    RefreshConfig    `yaml:"refresh"`
+	Review     ReviewConfig     `yaml:"review"`
 }
 
 type ServerConfig struct {
-	Port string `env:"SERVER_PORT" env-required:"true"`
+	Port               string        `env:"SERVER_PORT" env-required:"true"`
+	RequestTimeout     time.Duration `env:"SERVER_REQUEST_TIMEOUT" env-default:"30s" env-description:"Deadline budget for a single request, 0 disables it"`
+	BulkRequestTimeout time.Duration `env:"SERVER_BULK_REQUEST_TIMEOUT" env-default:"10m" env-description:"Deadline budget for batch create and bulk re-enrichment requests, 0 disables it"`
 }
 
 type DatabaseConfig struct {
@@ -32,6 +41,81 @@ type LoggerConfig struct {
 	Level string `env:"LOG_LEVEL" env-default:"info" env-description:"Logger level (debug, info, warn, error)"`
 }
 
+type CacheConfig struct {
+	Enabled        bool          `env:"CACHE_ENABLED" env-default:"true" env-description:"Cache enrichment lookups"`
+	Size           int           `env:"CACHE_SIZE" env-default:"10000" env-description:"Max entries in the in-memory tier"`
+	AgeTTL         time.Duration `env:"CACHE_AGE_TTL" env-default:"24h"`
+	GenderTTL      time.Duration `env:"CACHE_GENDER_TTL" env-default:"168h"`
+	NationalityTTL time.Duration `env:"CACHE_NATIONALITY_TTL" env-default:"168h"`
+	NegativeTTL    time.Duration `env:"CACHE_NEGATIVE_TTL" env-default:"1h" env-description:"How long names without a prediction are remembered"`
+	Shared         bool          `env:"CACHE_SHARED" env-default:"false" env-description:"Use the Postgres-backed tier shared across instances"`
+}
+
+type ExternalConfig struct {
+	Timeout        time.Duration  `env:"EXTERNAL_API_TIMEOUT" env-default:"10s"`
+	MaxConcurrency int            `env:"EXTERNAL_MAX_CONCURRENCY" env-default:"20" env-description:"Maximum outbound provider requests in flight, 0 disables the limit"`
+	Agify          ProviderConfig `yaml:"agify" env-prefix:"AGIFY_"`
+	Genderize      ProviderConfig `yaml:"genderize" env-prefix:"GENDERIZE_"`
+	Nationalize    ProviderConfig `yaml:"nationalize" env-prefix:"NATIONALIZE_"`
+}
+
+type ProviderConfig struct {
+	BaseURL          string        `env:"BASE_URL" env-description:"Override the provider endpoint, e.g. to point at cmd/mockapi"`
+	APIKey           string        `env:"API_KEY" env-description:"Sent as the apikey query parameter"`
+	DailyLimit       int           `env:"DAILY_LIMIT" env-default:"0" env-description:"Requests per UTC day shared by all instances, 0 only tracks usage"`
+	MaxRetries       int           `env:"MAX_RETRIES" env-default:"3"`
+	RetryBaseDelay   time.Duration `env:"RETRY_BASE_DELAY" env-default:"200ms"`
+	RetryMaxDelay    time.Duration `env:"RETRY_MAX_DELAY" env-default:"5s" env-description:"Upper bound for backoff and Retry-After waits"`
+	BreakerThreshold int           `env:"BREAKER_THRESHOLD" env-default:"5" env-description:"Consecutive failures before the circuit opens"`
+	BreakerCooldown  time.Duration `env:"BREAKER_COOLDOWN" env-default:"30s"`
+}
+
+type EnrichmentConfig struct {
+	Provider               string        `env:"ENRICHMENT_PROVIDER" env-default:"api" env-description:"Enrichment source: api or local"`
+	Transliteration        string        `env:"ENRICHMENT_TRANSLITERATION" env-default:"bgn" env-description:"Cyrillic to Latin scheme applied before lookup: none, bgn, icao or gost"`
+	DefaultCountry         string        `env:"ENRICHMENT_DEFAULT_COUNTRY" env-description:"ISO 3166-1 alpha-2 country sent as country_id to agify and genderize when the request has no hint"`
+	CountryFromNationality bool          `env:"ENRICHMENT_COUNTRY_FROM_NATIONALITY" env-default:"false" env-description:"Call nationalize first and localize age and gender by its top country"`
+	DatasetPath            string        `env:"ENRICHMENT_DATASET_PATH" env-default:"data/names.csv" env-description:"CSV or JSON name dataset used by the local provider"`
+	DiminutiveReload       time.Duration `env:"ENRICHMENT_DIMINUTIVE_RELOAD" env-default:"1m" env-description:"How often the diminutive dictionary is reloaded to pick up edits made on other instances, 0 disables"`
+	Async                  bool          `env:"ENRICHMENT_ASYNC" env-default:"false" env-description:"Enrich new persons in background workers"`
+	Workers                int           `env:"ENRICHMENT_WORKERS" env-default:"4"`
+	PollInterval           time.Duration `env:"ENRICHMENT_POLL_INTERVAL" env-default:"1s"`
+	MaxAttempts            int           `env:"ENRICHMENT_MAX_ATTEMPTS" env-default:"5" env-description:"Attempts before a job is moved to the dead-letter state"`
+	RetryDelay             time.Duration `env:"ENRICHMENT_RETRY_DELAY" env-default:"10s"`
+	MaxRetryDelay          time.Duration `env:"ENRICHMENT_MAX_RETRY_DELAY" env-default:"10m" env-description:"Upper bound for the exponential delay between job attempts"`
+	JobTimeout             time.Duration `env:"ENRICHMENT_JOB_TIMEOUT" env-default:"1m"`
+	Age                    ChainConfig   `yaml:"age" env-prefix:"ENRICHMENT_AGE_"`
+	Gender                 ChainConfig   `yaml:"gender" env-prefix:"ENRICHMENT_GENDER_"`
+	Nationality            ChainConfig   `yaml:"nationality" env-prefix:"ENRICHMENT_NATIONALITY_"`
+	Surname                SurnameConfig `yaml:"surname" env-prefix:"ENRICHMENT_SURNAME_"`
+}
+
+type ChainConfig struct {
+	Strategy string   `env:"STRATEGY" env-default:"fallback" env-description:"How sources are combined: fallback or ensemble"`
+	Sources  []string `env:"SOURCES" env-description:"Ordered sources with optional weights, e.g. api:2,local:1 (gender also accepts rules); defaults to ENRICHMENT_PROVIDER"`
+}
+
+type SurnameConfig struct {
+	Source      string  `env:"SOURCE" env-default:"off" env-description:"Surname nationality source: off, api or local"`
+	DatasetPath string  `env:"DATASET_PATH" env-default:"data/surnames.csv" env-description:"CSV or JSON surname dataset used by the local source"`
+	Weight      float64 `env:"WEIGHT" env-default:"0.5" env-description:"Share of the surname distribution in the merged nationality, the first name gets the rest"`
+}
+
+type ReviewConfig struct {
+	MinAgeCount               int     `env:"REVIEW_MIN_AGE_COUNT" env-default:"0" env-description:"Agify predictions with fewer samples go to the review queue"`
+	MinGenderProbability      float64 `env:"REVIEW_MIN_GENDER_PROBABILITY" env-default:"0.7"`
+	MinGenderCount            int     `env:"REVIEW_MIN_GENDER_COUNT" env-default:"0"`
+	MinNationalityProbability float64 `env:"REVIEW_MIN_NATIONALITY_PROBABILITY" env-default:"0" env-description:"Applies to the most probable country"`
+	MinNationalityCount       int     `env:"REVIEW_MIN_NATIONALITY_COUNT" env-default:"0"`
+}
+
+type RefreshConfig struct {
+	Enabled   bool          `env:"REFRESH_ENABLED" env-default:"false" env-description:"Periodically re-enrich stale records"`
+	Interval  time.Duration `env:"REFRESH_INTERVAL" env-default:"1h"`
+	MaxAge    time.Duration `env:"REFRESH_MAX_AGE" env-default:"720h" env-description:"Records enriched earlier than this are refreshed"`
+	BatchSize int           `env:"REFRESH_BATCH_SIZE" env-default:"100"`
+}
+
 func MustLoad() (*Config, error) {
 	if _, err := os.Stat(".env"); err == nil {
 		if err := godotenv.Load(); err != nil {
@@ -46,9 +130,26 @@ func MustLoad() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
 
+	if err := cfg.Enrichment.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	return &cfg, nil
 }
 
+func (c *EnrichmentConfig) validate() error {
+	country := c.DefaultCountry
+	if country == "" {
+		return nil
+	}
+
+	if len(country) != 2 || strings.Trim(strings.ToUpper(country), "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return fmt.Errorf("ENRICHMENT_DEFAULT_COUNTRY must be an ISO 3166-1 alpha-2 code, got %q", country)
+	}
+
+	return nil
+}
+
 func (c *DatabaseConfig) GetDSN() string {
 	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
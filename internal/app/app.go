@@ -3,16 +3,31 @@ package app
 import (
 	"Name_IQ_Finder/config"
 	"Name_IQ_Finder/internal/controller/http"
+	"Name_IQ_Finder/internal/entity"
 	"Name_IQ_Finder/internal/infrastructure/api"
 	"Name_IQ_Finder/internal/infrastructure/repo"
+	"Name_IQ_Finder/internal/infrastructure/resilience"
 	"Name_IQ_Finder/internal/logger"
+	"Name_IQ_Finder/internal/normalize"
 	"Name_IQ_Finder/internal/usecase"
+	"context"
 	"database/sql"
+	"errors"
 	"log"
+	nethttp "net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 )
 
+const shutdownTimeout = 10 * time.Second
+
 func Run(cfg *config.Config) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	appLogger := logger.New(cfg.Logger.Level)
 	appLogger.Info("Starting Name IQ Finder service")
 
@@ -28,15 +43,134 @@ func Run(cfg *config.Config) {
 	appLogger.Info("Connected to database")
 
 	personRepo := repo.NewPostgresRepository(db)
+	jobRepo := repo.NewPostgresJobRepository(db)
+	diminutiveRepo := repo.NewPostgresDiminutiveRepository(db)
+	enrichmentLogRepo := repo.NewPostgresEnrichmentLogRepository(db)
+
+	sources, err := newEnrichmentSources(cfg, db, appLogger)
+	if err != nil {
+		appLogger.Fatal("Failed to configure enrichment sources: %v", err)
+	}
+
+	normalizer, err := normalize.New(cfg.Enrichment.Transliteration)
+	if err != nil {
+		appLogger.Fatal("Failed to configure name normalization: %v", err)
+	}
+
+	useCaseLogger := log.New(os.Stdout, "", log.LstdFlags)
+
+	diminutiveUseCase := usecase.NewDiminutiveUseCase(diminutiveRepo, useCaseLogger)
+	if err := diminutiveUseCase.Load(ctx); err != nil {
+		appLogger.Error("Failed to load diminutive dictionary: %v", err)
+	}
+
+	enricher := usecase.NewEnricher(sources.client, sources.surnames, normalizer, diminutiveUseCase, enrichmentLogRepo, usecase.EnricherConfig{
+		Thresholds: entity.Thresholds{
+			Age: entity.Threshold{
+				MinCount: cfg.Review.MinAgeCount,
+			},
+			Gender: entity.Threshold{
+				MinProbability: cfg.Review.MinGenderProbability,
+				MinCount:       cfg.Review.MinGenderCount,
+			},
+			Nationality: entity.Threshold{
+				MinProbability: cfg.Review.MinNationalityProbability,
+				MinCount:       cfg.Review.MinNationalityCount,
+			},
+		},
+		GenderRules:            sources.genderRules,
+		GenderFallback:         sources.genderFallback,
+		DefaultCountry:         cfg.Enrichment.DefaultCountry,
+		CountryFromNationality: cfg.Enrichment.CountryFromNationality,
+		SurnameWeight:          cfg.Enrichment.Surname.Weight,
+	})
+
+	personUseCase := usecase.NewPersonUseCase(personRepo, jobRepo, enricher, cfg.Enrichment.Async, useCaseLogger)
+
+	var background sync.WaitGroup
+
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		if err := personUseCase.BackfillCanonical(ctx); err != nil && ctx.Err() == nil {
+			appLogger.Error("Failed to backfill canonical names: %v", err)
+		}
+	}()
 
-	externalClient := api.NewExternalClient()
+	if cfg.Enrichment.DiminutiveReload > 0 {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			diminutiveUseCase.Watch(ctx, cfg.Enrichment.DiminutiveReload)
+		}()
+	}
+
+	if cfg.Enrichment.Async {
+		worker := usecase.NewEnrichmentWorker(personRepo, jobRepo, enricher, usecase.WorkerConfig{
+			Workers:       cfg.Enrichment.Workers,
+			PollInterval:  cfg.Enrichment.PollInterval,
+			MaxAttempts:   cfg.Enrichment.MaxAttempts,
+			RetryDelay:    cfg.Enrichment.RetryDelay,
+			MaxRetryDelay: cfg.Enrichment.MaxRetryDelay,
+			JobTimeout:    cfg.Enrichment.JobTimeout,
+		}, useCaseLogger)
+
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			worker.Run(ctx)
+		}()
+		appLogger.Info("Asynchronous enrichment enabled (workers=%d)", cfg.Enrichment.Workers)
+	}
 
-	personUseCase := usecase.NewPersonUseCase(personRepo, externalClient, log.New(os.Stdout, "", log.LstdFlags))
+	if cfg.Refresh.Enabled {
+		scheduler := usecase.NewRefreshScheduler(personUseCase, cfg.Refresh.Interval, cfg.Refresh.MaxAge, cfg.Refresh.BatchSize, useCaseLogger)
 
-	router := http.NewRouter(personUseCase)
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			scheduler.Run(ctx)
+		}()
+	}
+
+	router := http.NewRouter(personUseCase, diminutiveUseCase, sources.cache, sources.health, cfg.Server.RequestTimeout, cfg.Server.BulkRequestTimeout)
+
+	server := &nethttp.Server{
+		Addr:    ":" + cfg.Server.Port,
+		Handler: router,
+	}
+
+	go func() {
+		appLogger.Info("Starting server on port %s", cfg.Server.Port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+			appLogger.Fatal("Failed to start server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	appLogger.Info("Shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		appLogger.Error("Failed to shut down server: %v", err)
+	}
+
+	background.Wait()
+}
 
-	appLogger.Info("Starting server on port %s", cfg.Server.Port)
-	if err := router.Run(":" + cfg.Server.Port); err != nil {
-		appLogger.Fatal("Failed to start server: %v", err)
+func providerPolicy(cfg config.ProviderConfig) api.ProviderPolicy {
+	return api.ProviderPolicy{
+		BaseURL:    cfg.BaseURL,
+		APIKey:     cfg.APIKey,
+		DailyLimit: cfg.DailyLimit,
+		Retry: resilience.RetryPolicy{
+			MaxRetries: cfg.MaxRetries,
+			BaseDelay:  cfg.RetryBaseDelay,
+			MaxDelay:   cfg.RetryMaxDelay,
+		},
+		BreakerThreshold: cfg.BreakerThreshold,
+		BreakerCooldown:  cfg.BreakerCooldown,
 	}
 }
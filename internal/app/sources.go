@@ -0,0 +1,232 @@
+package app
+
+import (
+	"Name_IQ_Finder/config"
+	"Name_IQ_Finder/internal/entity"
+	"Name_IQ_Finder/internal/infrastructure/api"
+	"Name_IQ_Finder/internal/infrastructure/cache"
+	"Name_IQ_Finder/internal/infrastructure/composite"
+	"Name_IQ_Finder/internal/infrastructure/dataset"
+	"Name_IQ_Finder/internal/infrastructure/repo"
+	"Name_IQ_Finder/internal/logger"
+	"Name_IQ_Finder/internal/usecase"
+	"database/sql"
+	"fmt"
+	"slices"
+)
+
+const (
+	sourceAPI   = "api"
+	sourceLocal = "local"
+	sourceRules = "rules"
+	sourceOff   = "off"
+)
+
+type enrichmentSources struct {
+	client         entity.ExternalAPIClient
+	surnames       entity.NationalityPredictor
+	cache          entity.EnrichmentCache
+	health         entity.ProviderHealth
+	genderRules    string
+	genderFallback entity.GenderPredictor
+
+	cfg     *config.Config
+	db      *sql.DB
+	logger  *logger.Logger
+	clients map[string]entity.ExternalAPIClient
+}
+
+func newEnrichmentSources(cfg *config.Config, db *sql.DB, appLogger *logger.Logger) (*enrichmentSources, error) {
+	s := &enrichmentSources{
+		cfg:     cfg,
+		db:      db,
+		logger:  appLogger,
+		clients: make(map[string]entity.ExternalAPIClient),
+	}
+
+	age, err := s.chain(cfg.Enrichment.Age, false)
+	if err != nil {
+		return nil, fmt.Errorf("age sources: %w", err)
+	}
+	gender, err := s.chain(cfg.Enrichment.Gender, true)
+	if err != nil {
+		return nil, fmt.Errorf("gender sources: %w", err)
+	}
+	nationality, err := s.chain(cfg.Enrichment.Nationality, false)
+	if err != nil {
+		return nil, fmt.Errorf("nationality sources: %w", err)
+	}
+
+	if err := s.surnameSource(cfg.Enrichment.Surname); err != nil {
+		return nil, fmt.Errorf("surname source: %w", err)
+	}
+
+	if single(age) && single(gender) && single(nationality) &&
+		age.Sources[0].Name == gender.Sources[0].Name && age.Sources[0].Name == nationality.Sources[0].Name {
+		s.client = age.Sources[0].Client
+		return s, nil
+	}
+
+	s.client = composite.NewClient(age, gender, nationality)
+	appLogger.Info("Enrichment sources: age=%s, gender=%s (rules=%s), nationality=%s", describe(age), describe(gender), s.genderRules, describe(nationality))
+	return s, nil
+}
+
+func (s *enrichmentSources) chain(cfg config.ChainConfig, allowRules bool) (composite.Chain, error) {
+	specs, err := composite.ParseSources(cfg.Sources)
+	if err != nil {
+		return composite.Chain{}, err
+	}
+	if len(specs) == 0 {
+		specs = []composite.SourceSpec{{Name: s.cfg.Enrichment.Provider, Weight: 1}}
+		if allowRules {
+			specs = append([]composite.SourceSpec{{Name: sourceRules}}, specs...)
+		}
+	}
+
+	rules := slices.IndexFunc(specs, func(spec composite.SourceSpec) bool {
+		return spec.Name == sourceRules
+	})
+	if !allowRules {
+		if rules >= 0 {
+			return composite.Chain{}, fmt.Errorf("%s only applies to gender", sourceRules)
+		}
+		return s.sourceChain(cfg.Strategy, specs)
+	}
+
+	switch {
+	case rules < 0:
+		s.genderRules = usecase.GenderRulesOff
+		return s.sourceChain(cfg.Strategy, specs)
+	case rules == 0:
+		s.genderRules = usecase.GenderRulesFirst
+		return s.sourceChain(cfg.Strategy, specs[1:])
+	}
+
+	s.genderRules = usecase.GenderRulesFallback
+	if after := specs[rules+1:]; len(after) > 0 {
+		fallback, err := s.sourceChain(cfg.Strategy, after)
+		if err != nil {
+			return composite.Chain{}, err
+		}
+		s.genderFallback = fallback
+	}
+
+	return s.sourceChain(cfg.Strategy, specs[:rules])
+}
+
+func (s *enrichmentSources) sourceChain(strategy string, specs []composite.SourceSpec) (composite.Chain, error) {
+	sources := make([]composite.Source, 0, len(specs))
+	for _, spec := range specs {
+		client, err := s.source(spec.Name)
+		if err != nil {
+			return composite.Chain{}, err
+		}
+		sources = append(sources, composite.Source{
+			Name:   spec.Name,
+			Weight: spec.Weight,
+			Client: client,
+		})
+	}
+
+	return composite.NewChain(strategy, sources)
+}
+
+func (s *enrichmentSources) source(name string) (entity.ExternalAPIClient, error) {
+	if client, ok := s.clients[name]; ok {
+		return client, nil
+	}
+
+	var client entity.ExternalAPIClient
+	switch name {
+	case sourceLocal:
+		names, err := dataset.Load(s.cfg.Enrichment.DatasetPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load name dataset: %w", err)
+		}
+		client = dataset.NewClient(names)
+		s.logger.Info("Using local name dataset %s (%d names)", s.cfg.Enrichment.DatasetPath, names.Len())
+	case sourceAPI:
+		apiClient := api.NewExternalClient(s.cfg.External.Timeout, s.cfg.External.MaxConcurrency, api.Policies{
+			Agify:       providerPolicy(s.cfg.External.Agify),
+			Genderize:   providerPolicy(s.cfg.External.Genderize),
+			Nationalize: providerPolicy(s.cfg.External.Nationalize),
+		}, repo.NewPostgresQuotaRepository(s.db), s.logger)
+		client = apiClient
+		s.health = apiClient
+
+		if s.cfg.Cache.Enabled {
+			client = s.cached(apiClient)
+		}
+	default:
+		return nil, fmt.Errorf("unknown enrichment source %q", name)
+	}
+
+	s.clients[name] = client
+	return client, nil
+}
+
+func (s *enrichmentSources) surnameSource(cfg config.SurnameConfig) error {
+	switch cfg.Source {
+	case sourceOff, "":
+		return nil
+	case sourceAPI:
+		client, err := s.source(sourceAPI)
+		if err != nil {
+			return err
+		}
+		s.surnames = client
+	case sourceLocal:
+		surnames, err := dataset.Load(cfg.DatasetPath)
+		if err != nil {
+			return fmt.Errorf("failed to load surname dataset: %w", err)
+		}
+		s.surnames = dataset.NewClient(surnames)
+		s.logger.Info("Using local surname dataset %s (%d surnames)", cfg.DatasetPath, surnames.Len())
+	default:
+		return fmt.Errorf("unknown surname source %q", cfg.Source)
+	}
+
+	s.logger.Info("Surname nationality enabled (source=%s, weight=%g)", cfg.Source, cfg.Weight)
+	return nil
+}
+
+func (s *enrichmentSources) cached(next entity.ExternalAPIClient) entity.ExternalAPIClient {
+	var sharedStore *cache.PostgresStore
+	if s.cfg.Cache.Shared {
+		sharedStore = cache.NewPostgresStore(s.db)
+	}
+
+	cachedClient := cache.NewClient(
+		next,
+		cache.NewLRU(s.cfg.Cache.Size),
+		sharedStore,
+		cache.TTLs{
+			Age:         s.cfg.Cache.AgeTTL,
+			Gender:      s.cfg.Cache.GenderTTL,
+			Nationality: s.cfg.Cache.NationalityTTL,
+			Negative:    s.cfg.Cache.NegativeTTL,
+		},
+		s.logger,
+	)
+	s.cache = cachedClient
+	s.logger.Info("Enrichment cache enabled (size=%d, shared=%t)", s.cfg.Cache.Size, s.cfg.Cache.Shared)
+
+	return cachedClient
+}
+
+func single(chain composite.Chain) bool {
+	return chain.Strategy == composite.StrategyFallback && len(chain.Sources) == 1
+}
+
+func describe(chain composite.Chain) string {
+	description := chain.Strategy + "("
+	for i, source := range chain.Sources {
+		if i > 0 {
+			description += ","
+		}
+		description += fmt.Sprintf("%s:%g", source.Name, source.Weight)
+	}
+
+	return description + ")"
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"testing"
+
+	"Name_IQ_Finder/config"
+	"Name_IQ_Finder/internal/entity"
+	"Name_IQ_Finder/internal/infrastructure/composite"
+	"Name_IQ_Finder/internal/logger"
+	"Name_IQ_Finder/internal/usecase"
+)
+
+func TestGenderChainDefaultsToRulesFirst(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Enrichment.Provider = sourceLocal
+	cfg.Enrichment.DatasetPath = "../../data/names.csv"
+
+	s := &enrichmentSources{
+		cfg:     cfg,
+		logger:  logger.New("error"),
+		clients: make(map[string]entity.ExternalAPIClient),
+	}
+
+	chain, err := s.chain(config.ChainConfig{Strategy: composite.StrategyFallback}, true)
+	if err != nil {
+		t.Fatalf("chain() error = %v", err)
+	}
+
+	if s.genderRules != usecase.GenderRulesFirst {
+		t.Errorf("gender rules = %q, want %q", s.genderRules, usecase.GenderRulesFirst)
+	}
+	if len(chain.Sources) != 1 || chain.Sources[0].Name != sourceLocal {
+		t.Errorf("sources = %s, want the provider alone after the rules", describe(chain))
+	}
+}
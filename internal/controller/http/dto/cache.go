@@ -0,0 +1,9 @@
+package dto
+
+type CacheStatsResponse struct {
+	Hits       int64 `json:"hits"`
+	Misses     int64 `json:"misses"`
+	MemoryHits int64 `json:"memory_hits"`
+	SharedHits int64 `json:"shared_hits"`
+	Entries    int   `json:"entries"`
+}
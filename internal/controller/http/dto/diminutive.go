@@ -0,0 +1,17 @@
+package dto
+
+type CreateDiminutiveRequest struct {
+	Diminutive string `json:"diminutive" binding:"required,max=100"`
+	Canonical  string `json:"canonical" binding:"required,max=100"`
+}
+
+type UpdateDiminutiveRequest struct {
+	Canonical string `json:"canonical" binding:"required,max=100"`
+}
+
+type DiminutiveResponse struct {
+	Diminutive string `json:"diminutive"`
+	Canonical  string `json:"canonical"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
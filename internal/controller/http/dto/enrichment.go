@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+type FieldChangeResponse struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+type EnrichmentReportResponse struct {
+	PersonID      int64                 `json:"person_id"`
+	Changes       []FieldChangeResponse `json:"changes"`
+	MissingFields []string              `json:"missing_fields,omitempty"`
+	Error         string                `json:"error,omitempty"`
+}
+
+type ReenrichResponse struct {
+	Person PersonResponse           `json:"person"`
+	Report EnrichmentReportResponse `json:"report"`
+}
+
+type RefreshReportResponse struct {
+	StartedAt  time.Time                  `json:"started_at"`
+	FinishedAt time.Time                  `json:"finished_at"`
+	Processed  int                        `json:"processed"`
+	Changed    int                        `json:"changed"`
+	Failed     int                        `json:"failed"`
+	Persons    []EnrichmentReportResponse `json:"persons"`
+}
+
+type EnrichmentLogEntryResponse struct {
+	ID           int64  `json:"id"`
+	Provider     string `json:"provider"`
+	RequestURL   string `json:"request_url"`
+	StatusCode   int    `json:"status_code"`
+	LatencyMs    int64  `json:"latency_ms"`
+	ResponseBody string `json:"response_body"`
+	Error        string `json:"error,omitempty"`
+	CreatedAt    string `json:"created_at"`
+}
+
+type EnrichmentHistoryResponse struct {
+	PersonID int64                        `json:"person_id"`
+	Entries  []EnrichmentLogEntryResponse `json:"entries"`
+}
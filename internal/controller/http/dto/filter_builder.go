@@ -1,36 +1,181 @@
 package dto
 
+import (
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
 type FilterBuilder struct {
-	filter map[string]interface{}
+	filter *entity.Filter
 }
 
 func NewFilterBuilder() *FilterBuilder {
 	return &FilterBuilder{
-		filter: make(map[string]interface{}),
+		filter: entity.NewFilter(),
 	}
 }
 
 func (b *FilterBuilder) WithName(name string) *FilterBuilder {
 	if name != "" {
-		b.filter["name"] = name
+		b.filter.Eq(entity.FilterName, name)
 	}
 	return b
 }
 
 func (b *FilterBuilder) WithSurname(surname string) *FilterBuilder {
 	if surname != "" {
-		b.filter["surname"] = surname
+		b.filter.Eq(entity.FilterSurname, surname)
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithPatronymic(patronymic string) *FilterBuilder {
+	if patronymic != "" {
+		b.filter.Eq(entity.FilterPatronymic, patronymic)
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithNamePrefix(field entity.FilterField, prefix string) *FilterBuilder {
+	if prefix != "" {
+		b.filter.Prefix(field, prefix)
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithNameContains(field entity.FilterField, substring string) *FilterBuilder {
+	if substring != "" {
+		b.filter.Contains(field, substring)
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithAgeRange(minAge, maxAge *int) *FilterBuilder {
+	if minAge != nil || maxAge != nil {
+		b.filter.Range(entity.FilterAge, bound(minAge), bound(maxAge))
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithGender(gender string) *FilterBuilder {
+	if gender != "" {
+		b.filter.Eq(entity.FilterGender, gender)
 	}
 	return b
 }
 
 func (b *FilterBuilder) WithNationality(nationality string) *FilterBuilder {
 	if nationality != "" {
-		b.filter["nationality"] = nationality
+		b.filter.Eq(entity.FilterNationality, nationality)
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithNationalities(nationalities []string) *FilterBuilder {
+	switch {
+	case len(nationalities) == 1:
+		b.filter.Eq(entity.FilterNationality, nationalities[0])
+	case len(nationalities) > 1:
+		values := make([]any, len(nationalities))
+		for i, nationality := range nationalities {
+			values[i] = nationality
+		}
+		b.filter.In(entity.FilterNationality, values...)
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithText(text string) *FilterBuilder {
+	if text != "" {
+		b.filter.Contains(entity.FilterText, text)
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithCountry(country string) *FilterBuilder {
+	if country != "" {
+		b.filter.Eq(entity.FilterCountry, country)
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithMinGenderProbability(probability *float64) *FilterBuilder {
+	if probability != nil {
+		b.filter.Range(entity.FilterGenderProbability, *probability, nil)
 	}
 	return b
 }
 
-func (b *FilterBuilder) Build() map[string]interface{} {
-	return b.filter
+func (b *FilterBuilder) WithMinAgeCount(count *int) *FilterBuilder {
+	if count != nil {
+		b.filter.Range(entity.FilterAgeCount, *count, nil)
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithMinGenderCount(count *int) *FilterBuilder {
+	if count != nil {
+		b.filter.Range(entity.FilterGenderCount, *count, nil)
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithEnrichmentStatus(status string) *FilterBuilder {
+	if status != "" {
+		b.filter.Eq(entity.FilterEnrichmentStatus, status)
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithNeedsReview(needsReview *bool) *FilterBuilder {
+	if needsReview != nil {
+		b.filter.Eq(entity.FilterNeedsReview, *needsReview)
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithCreatedRange(from, to *time.Time) *FilterBuilder {
+	return b.withTimeRange(entity.FilterCreatedAt, from, to)
+}
+
+func (b *FilterBuilder) WithCreatedBefore(before *time.Time) *FilterBuilder {
+	if before != nil {
+		b.filter.Conditions = append(b.filter.Conditions, entity.Condition{Field: entity.FilterCreatedAt, Op: entity.OpLt, Value: *before})
+	}
+	return b
+}
+
+func (b *FilterBuilder) WithUpdatedRange(from, to *time.Time) *FilterBuilder {
+	return b.withTimeRange(entity.FilterUpdatedAt, from, to)
+}
+
+func (b *FilterBuilder) WithEnrichedRange(from, to *time.Time) *FilterBuilder {
+	return b.withTimeRange(entity.FilterEnrichedAt, from, to)
+}
+
+func (b *FilterBuilder) WithCondition(condition entity.Condition) *FilterBuilder {
+	b.filter.Conditions = append(b.filter.Conditions, condition)
+	return b
+}
+
+func (b *FilterBuilder) Build() (*entity.Filter, error) {
+	if err := b.filter.Validate(); err != nil {
+		return nil, err
+	}
+	return b.filter, nil
+}
+
+func (b *FilterBuilder) withTimeRange(field entity.FilterField, from, to *time.Time) *FilterBuilder {
+	if from != nil || to != nil {
+		b.filter.Range(field, bound(from), bound(to))
+	}
+	return b
+}
+
+func bound[T any](value *T) any {
+	if value == nil {
+		return nil
+	}
+	return *value
 }
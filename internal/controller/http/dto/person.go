@@ -1,9 +1,16 @@
 package dto
 
+import "time"
+
 type CreatePersonRequest struct {
 	Name       string `json:"name" binding:"required"`
 	Surname    string `json:"surname" binding:"required"`
 	Patronymic string `json:"patronymic,omitempty"`
+	Country    string `json:"country,omitempty" binding:"omitempty,len=2,alpha" example:"RU"`
+}
+
+type CreatePersonBatchRequest struct {
+	Persons []CreatePersonRequest `json:"persons" binding:"required,min=1,max=500,dive"`
 }
 
 type UpdatePersonRequest struct {
@@ -15,16 +22,40 @@ type UpdatePersonRequest struct {
 	Nationality string `json:"nationality,omitempty"`
 }
 
+type CountryProbabilityResponse struct {
+	CountryID   string  `json:"country_id"`
+	Probability float64 `json:"probability"`
+}
+
 type PersonResponse struct {
-	ID          int64  `json:"id"`
-	Name        string `json:"name"`
-	Surname     string `json:"surname"`
-	Patronymic  string `json:"patronymic,omitempty"`
-	Age         int    `json:"age"`
-	Gender      string `json:"gender"`
-	Nationality string `json:"nationality"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID                  int64                        `json:"id"`
+	Name                string                       `json:"name"`
+	ResolvedName        string                       `json:"resolved_name,omitempty"`
+	CanonicalName       string                       `json:"canonical_name"`
+	Surname             string                       `json:"surname"`
+	Patronymic          string                       `json:"patronymic,omitempty"`
+	Age                 *int                         `json:"age"`
+	AgeCount            int                          `json:"age_count"`
+	AgeSource           string                       `json:"age_source,omitempty"`
+	AgeConfidence       float64                      `json:"age_confidence,omitempty"`
+	Gender              *string                      `json:"gender"`
+	GenderProbability   float64                      `json:"gender_probability"`
+	GenderCount         int                          `json:"gender_count"`
+	GenderSource        string                       `json:"gender_source,omitempty"`
+	Nationality         *string                      `json:"nationality"`
+	Countries           []CountryProbabilityResponse `json:"countries"`
+	NameCountries       []CountryProbabilityResponse `json:"name_countries,omitempty"`
+	SurnameCountries    []CountryProbabilityResponse `json:"surname_countries,omitempty"`
+	NationalitySource   string                       `json:"nationality_source,omitempty"`
+	CountryHint         string                       `json:"country_hint,omitempty"`
+	LocalizationCountry string                       `json:"localization_country,omitempty"`
+	EnrichmentStatus    string                       `json:"enrichment_status"`
+	NeedsReview         bool                         `json:"needs_review"`
+	Review              *ReviewResponse              `json:"review,omitempty"`
+	MissingFields       []string                     `json:"missing_fields,omitempty"`
+	Score               *float64                     `json:"score,omitempty" example:"0.82"`
+	CreatedAt           string                       `json:"created_at"`
+	UpdatedAt           string                       `json:"updated_at"`
 }
 
 type PersonListResponse struct {
@@ -33,3 +64,48 @@ type PersonListResponse struct {
 	Page       int              `json:"page"`
 	Limit      int              `json:"limit"`
 }
+
+type BatchItemResponse struct {
+	Index  int             `json:"index"`
+	Person *PersonResponse `json:"person,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type CreatePersonBatchResponse struct {
+	Results []BatchItemResponse `json:"results"`
+	Created int                 `json:"created"`
+	Failed  int                 `json:"failed"`
+}
+
+type CreatePersonAcceptedResponse struct {
+	Person    PersonResponse `json:"person"`
+	StatusURL string         `json:"status_url"`
+}
+
+type EnrichmentJobResponse struct {
+	PersonID  int64     `json:"person_id"`
+	Status    string    `json:"status"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	RunAt     time.Time `json:"run_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ProposedValueResponse struct {
+	Value       interface{} `json:"value"`
+	Probability float64     `json:"probability,omitempty"`
+	Count       int         `json:"count"`
+	Source      string      `json:"source"`
+}
+
+type ReviewResponse struct {
+	Age         *ProposedValueResponse `json:"age,omitempty"`
+	Gender      *ProposedValueResponse `json:"gender,omitempty"`
+	Nationality *ProposedValueResponse `json:"nationality,omitempty"`
+}
+
+type ResolveReviewRequest struct {
+	Field  string `json:"field" binding:"required,oneof=age gender nationality"`
+	Action string `json:"action" binding:"required,oneof=accept override"`
+	Value  string `json:"value,omitempty"`
+}
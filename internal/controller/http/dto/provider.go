@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+type ProviderStatusResponse struct {
+	Provider string     `json:"provider"`
+	State    string     `json:"state"`
+	Failures int        `json:"failures"`
+	OpenedAt *time.Time `json:"opened_at,omitempty"`
+}
+
+type QuotaUsageResponse struct {
+	Provider  string     `json:"provider"`
+	Day       string     `json:"day"`
+	Used      int        `json:"used"`
+	Limit     int        `json:"limit"`
+	Remaining *int       `json:"remaining,omitempty"`
+	ResetAt   *time.Time `json:"reset_at,omitempty"`
+}
@@ -0,0 +1,223 @@
+package dto
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+type SearchRequest struct {
+	Query *SearchNode  `json:"query"`
+	Sort  []SearchSort `json:"sort,omitempty"`
+	Page  int          `json:"page,omitempty" binding:"omitempty,min=1" example:"1"`
+	Limit int          `json:"limit,omitempty" binding:"omitempty,min=1,max=100" example:"20"`
+}
+
+type SearchNode struct {
+	And   []*SearchNode `json:"and,omitempty"`
+	Or    []*SearchNode `json:"or,omitempty"`
+	Not   *SearchNode   `json:"not,omitempty"`
+	Field string        `json:"field,omitempty" example:"age"`
+	Op    string        `json:"op,omitempty" enums:"eq,ne,in,gt,gte,lt,lte,range,prefix,contains,is_null,not_null" example:"gt"`
+	Value interface{}   `json:"value,omitempty"`
+}
+
+type SearchSort struct {
+	Field string `json:"field" example:"age"`
+	Order string `json:"order,omitempty" enums:"asc,desc" example:"desc"`
+}
+
+func (r *SearchRequest) ToQuery() (*entity.SearchQuery, error) {
+	query := &entity.SearchQuery{
+		Page:  r.Page,
+		Limit: r.Limit,
+	}
+
+	if r.Query != nil {
+		complexity := 0
+		where, err := r.Query.toNode(1, &complexity)
+		if err != nil {
+			return nil, err
+		}
+		query.Where = where
+	}
+
+	for _, sort := range r.Sort {
+		if sort.Order != "" && sort.Order != "asc" && sort.Order != "desc" {
+			return nil, fmt.Errorf("%w: sort order %q must be asc or desc", entity.ErrInvalidFilter, sort.Order)
+		}
+		query.Sort = append(query.Sort, entity.SortOrder{
+			Field: entity.FilterField(sort.Field),
+			Desc:  sort.Order == "desc",
+		})
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+func (n *SearchNode) toNode(depth int, complexity *int) (*entity.FilterNode, error) {
+	if n == nil {
+		return nil, fmt.Errorf("%w: empty operand", entity.ErrInvalidFilter)
+	}
+	if depth > entity.MaxSearchDepth {
+		return nil, fmt.Errorf("%w: query is nested deeper than %d levels", entity.ErrInvalidFilter, entity.MaxSearchDepth)
+	}
+	if err := addComplexity(complexity, 1); err != nil {
+		return nil, err
+	}
+
+	set := 0
+	for _, present := range []bool{n.And != nil, n.Or != nil, n.Not != nil, n.Field != ""} {
+		if present {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("%w: each node must be exactly one of and, or, not or a field condition", entity.ErrInvalidFilter)
+	}
+
+	node := &entity.FilterNode{}
+	var err error
+
+	switch {
+	case n.And != nil:
+		node.And, err = toNodes(n.And, depth, complexity)
+	case n.Or != nil:
+		node.Or, err = toNodes(n.Or, depth, complexity)
+	case n.Not != nil:
+		node.Not, err = n.Not.toNode(depth+1, complexity)
+	default:
+		node.Condition, err = n.toCondition(complexity)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func toNodes(children []*SearchNode, depth int, complexity *int) ([]*entity.FilterNode, error) {
+	if *complexity+len(children) > entity.MaxSearchComplexity {
+		return nil, complexityError()
+	}
+
+	nodes := make([]*entity.FilterNode, len(children))
+	for i, child := range children {
+		node, err := child.toNode(depth+1, complexity)
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = node
+	}
+
+	return nodes, nil
+}
+
+func addComplexity(complexity *int, n int) error {
+	*complexity += n
+	if *complexity > entity.MaxSearchComplexity {
+		return complexityError()
+	}
+
+	return nil
+}
+
+func complexityError() error {
+	return fmt.Errorf("%w: query has more than %d nodes and values", entity.ErrInvalidFilter, entity.MaxSearchComplexity)
+}
+
+func (n *SearchNode) toCondition(complexity *int) (*entity.Condition, error) {
+	field := entity.FilterField(n.Field)
+	spec, ok := entity.FilterFields[field]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported field %q", entity.ErrInvalidFilter, n.Field)
+	}
+
+	condition := &entity.Condition{
+		Field: field,
+		Op:    entity.FilterOp(n.Op),
+	}
+	if !spec.Supports(condition.Op) {
+		return nil, fmt.Errorf("%w: operator %q is not supported for %s", entity.ErrInvalidFilter, n.Op, n.Field)
+	}
+
+	var err error
+	switch condition.Op {
+	case entity.OpIsNull, entity.OpNotNull:
+	case entity.OpIn:
+		values, ok := n.Value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s in expects an array", entity.ErrInvalidFilter, n.Field)
+		}
+		if err := addComplexity(complexity, len(values)); err != nil {
+			return nil, err
+		}
+		condition.Values = make([]any, len(values))
+		for i, value := range values {
+			if condition.Values[i], err = searchValue(n.Field, spec.Kind, value); err != nil {
+				return nil, err
+			}
+		}
+	case entity.OpRange:
+		bounds, ok := n.Value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s range expects {\"from\": ..., \"to\": ...}", entity.ErrInvalidFilter, n.Field)
+		}
+		if from, ok := bounds["from"]; ok && from != nil {
+			if condition.From, err = searchValue(n.Field, spec.Kind, from); err != nil {
+				return nil, err
+			}
+		}
+		if to, ok := bounds["to"]; ok && to != nil {
+			if condition.To, err = searchValue(n.Field, spec.Kind, to); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		if condition.Value, err = searchValue(n.Field, spec.Kind, n.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	return condition, nil
+}
+
+func searchValue(field string, kind entity.FieldKind, value interface{}) (any, error) {
+	switch kind {
+	case entity.KindInt:
+		if number, ok := value.(float64); ok && number == math.Trunc(number) && math.Abs(number) <= 1<<53 {
+			return int(number), nil
+		}
+		return nil, fmt.Errorf("%w: %s expects an integer, got %v", entity.ErrInvalidFilter, field, value)
+	case entity.KindFloat:
+		if number, ok := value.(float64); ok {
+			return number, nil
+		}
+		return nil, fmt.Errorf("%w: %s expects a number, got %v", entity.ErrInvalidFilter, field, value)
+	case entity.KindBool:
+		if flag, ok := value.(bool); ok {
+			return flag, nil
+		}
+		return nil, fmt.Errorf("%w: %s expects a boolean, got %v", entity.ErrInvalidFilter, field, value)
+	case entity.KindTime:
+		if raw, ok := value.(string); ok {
+			for _, layout := range []string{time.RFC3339, time.DateOnly} {
+				if parsed, err := time.Parse(layout, raw); err == nil {
+					return parsed, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("%w: %s expects an RFC 3339 timestamp or YYYY-MM-DD date, got %v", entity.ErrInvalidFilter, field, value)
+	default:
+		if text, ok := value.(string); ok {
+			return text, nil
+		}
+		return nil, fmt.Errorf("%w: %s expects a string, got %v", entity.ErrInvalidFilter, field, value)
+	}
+}
@@ -0,0 +1,6 @@
+package dto
+
+type SuggestionResponse struct {
+	Value string `json:"value" example:"Иванов"`
+	Count int    `json:"count" example:"42"`
+}
@@ -1,17 +1,22 @@
 package http
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	v1 "Name_IQ_Finder/internal/controller/http/v1"
 	"Name_IQ_Finder/internal/entity"
 )
 
-func NewRouter(useCase entity.PersonUseCase) *gin.Engine {
+func NewRouter(useCase entity.PersonUseCase, diminutives entity.DiminutiveUseCase, cache entity.EnrichmentCache, health entity.ProviderHealth, requestTimeout, bulkRequestTimeout time.Duration) *gin.Engine {
 	router := gin.Default()
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
-	v1.RegisterRoutes(router, useCase)
+	v1.RegisterRoutes(router, useCase, diminutives, cache, health, v1.Timeouts{
+		Request: RequestTimeout(requestTimeout),
+		Bulk:    RequestTimeout(bulkRequestTimeout),
+	})
 
 	return router
 }
@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"Name_IQ_Finder/internal/controller/http/dto"
+	"Name_IQ_Finder/internal/entity"
+)
+
+type CacheHandler struct {
+	cache entity.EnrichmentCache
+}
+
+func NewCacheHandler(cache entity.EnrichmentCache) *CacheHandler {
+	return &CacheHandler{
+		cache: cache,
+	}
+}
+
+// Stats godoc
+// @Summary      Get cache statistics
+// @Description  Get hit/miss counters of the enrichment cache
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  dto.CacheStatsResponse
+// @Router       /api/v1/admin/cache [get]
+func (h *CacheHandler) Stats(c *gin.Context) {
+	stats := h.cache.Stats()
+
+	c.JSON(http.StatusOK, dto.CacheStatsResponse{
+		Hits:       stats.Hits,
+		Misses:     stats.Misses,
+		MemoryHits: stats.MemoryHits,
+		SharedHits: stats.SharedHits,
+		Entries:    stats.Entries,
+	})
+}
+
+// Purge godoc
+// @Summary      Purge cache
+// @Description  Remove every entry from the enrichment cache
+// @Tags         admin
+// @Success      204  "No Content"
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/v1/admin/cache [delete]
+func (h *CacheHandler) Purge(c *gin.Context) {
+	if err := h.cache.Purge(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Invalidate godoc
+// @Summary      Invalidate cached name
+// @Description  Remove cached enrichment results of a single name
+// @Tags         admin
+// @Param        name  path  string  true  "Name"
+// @Success      204  "No Content"
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/v1/admin/cache/{name} [delete]
+func (h *CacheHandler) Invalidate(c *gin.Context) {
+	if err := h.cache.Invalidate(c.Request.Context(), c.Param("name")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
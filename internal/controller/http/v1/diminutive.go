@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"Name_IQ_Finder/internal/controller/http/dto"
+	"Name_IQ_Finder/internal/entity"
+)
+
+type DiminutiveHandler struct {
+	useCase entity.DiminutiveUseCase
+}
+
+func NewDiminutiveHandler(useCase entity.DiminutiveUseCase) *DiminutiveHandler {
+	return &DiminutiveHandler{
+		useCase: useCase,
+	}
+}
+
+// List godoc
+// @Summary      List diminutives
+// @Description  Get every diminutive to canonical first name mapping
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.DiminutiveResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/v1/admin/diminutives [get]
+func (h *DiminutiveHandler) List(c *gin.Context) {
+	diminutives, err := h.useCase.List(c.Request.Context())
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]dto.DiminutiveResponse, len(diminutives))
+	for i, diminutive := range diminutives {
+		response[i] = toDiminutiveResponse(diminutive)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Get godoc
+// @Summary      Get diminutive
+// @Description  Get the canonical first name of a diminutive
+// @Tags         admin
+// @Produce      json
+// @Param        diminutive  path      string  true  "Diminutive"
+// @Success      200         {object}  dto.DiminutiveResponse
+// @Failure      404         {object}  dto.ErrorResponse
+// @Failure      500         {object}  dto.ErrorResponse
+// @Router       /api/v1/admin/diminutives/{diminutive} [get]
+func (h *DiminutiveHandler) Get(c *gin.Context) {
+	diminutive, err := h.useCase.Get(c.Request.Context(), c.Param("diminutive"))
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toDiminutiveResponse(diminutive))
+}
+
+// Create godoc
+// @Summary      Create diminutive
+// @Description  Add a diminutive to canonical first name mapping
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateDiminutiveRequest  true  "Mapping"
+// @Success      201      {object}  dto.DiminutiveResponse
+// @Failure      400      {object}  dto.ErrorResponse
+// @Failure      409      {object}  dto.ErrorResponse
+// @Failure      500      {object}  dto.ErrorResponse
+// @Router       /api/v1/admin/diminutives [post]
+func (h *DiminutiveHandler) Create(c *gin.Context) {
+	var req dto.CreateDiminutiveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	diminutive, err := h.useCase.Create(c.Request.Context(), req.Diminutive, req.Canonical)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toDiminutiveResponse(diminutive))
+}
+
+// Update godoc
+// @Summary      Update diminutive
+// @Description  Change the canonical first name of a diminutive
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        diminutive  path      string                       true  "Diminutive"
+// @Param        request     body      dto.UpdateDiminutiveRequest  true  "Canonical name"
+// @Success      200         {object}  dto.DiminutiveResponse
+// @Failure      400         {object}  dto.ErrorResponse
+// @Failure      404         {object}  dto.ErrorResponse
+// @Failure      500         {object}  dto.ErrorResponse
+// @Router       /api/v1/admin/diminutives/{diminutive} [put]
+func (h *DiminutiveHandler) Update(c *gin.Context) {
+	var req dto.UpdateDiminutiveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	diminutive, err := h.useCase.Update(c.Request.Context(), c.Param("diminutive"), req.Canonical)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toDiminutiveResponse(diminutive))
+}
+
+// Delete godoc
+// @Summary      Delete diminutive
+// @Description  Remove a diminutive mapping
+// @Tags         admin
+// @Param        diminutive  path      string  true  "Diminutive"
+// @Success      204         "No Content"
+// @Failure      404         {object}  dto.ErrorResponse
+// @Failure      500         {object}  dto.ErrorResponse
+// @Router       /api/v1/admin/diminutives/{diminutive} [delete]
+func (h *DiminutiveHandler) Delete(c *gin.Context) {
+	if err := h.useCase.Delete(c.Request.Context(), c.Param("diminutive")); err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func toDiminutiveResponse(diminutive *entity.Diminutive) dto.DiminutiveResponse {
+	return dto.DiminutiveResponse{
+		Diminutive: diminutive.Diminutive,
+		Canonical:  diminutive.Canonical,
+		CreatedAt:  diminutive.CreatedAt,
+		UpdatedAt:  diminutive.UpdatedAt,
+	}
+}
@@ -0,0 +1,206 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"Name_IQ_Finder/internal/controller/http/dto"
+	"Name_IQ_Finder/internal/entity"
+)
+
+const (
+	maxReenrichLimit = 1000
+	maxHistoryLimit  = 1000
+)
+
+// Reenrich godoc
+// @Summary      Re-enrich person
+// @Description  Query the providers again for a person and store the new values
+// @Tags         enrichment
+// @Produce      json
+// @Param        id   path      int  true  "Person ID"
+// @Success      200  {object}  dto.ReenrichResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/v1/persons/{id}/enrich [post]
+func (h *PersonHandler) Reenrich(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if _, err := h.useCase.GetByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	person, report, err := h.useCase.Reenrich(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ReenrichResponse{
+		Person: toPersonResponse(person),
+		Report: toEnrichmentReportResponse(*report),
+	})
+}
+
+// ReenrichMany godoc
+// @Summary      Re-enrich persons in bulk
+// @Description  Re-enrich every person matching the filter, up to limit records
+// @Tags         enrichment
+// @Produce      json
+// @Param        limit                   query     int     false  "Maximum number of persons to re-enrich (default 100, max 1000)"
+// @Param        q                       query     string  false  "Case-insensitive substring of name, resolved name, surname or patronymic"
+// @Param        name                    query     string  false  "Filter by name"
+// @Param        surname                 query     string  false  "Filter by surname"
+// @Param        patronymic              query     string  false  "Filter by patronymic"
+// @Param        age_gte                 query     int     false  "Minimum age (inclusive)"
+// @Param        age_lte                 query     int     false  "Maximum age (inclusive)"
+// @Param        gender                  query     string  false  "Filter by gender"  Enums(male, female)
+// @Param        nationality             query     string  false  "Filter by nationality, comma-separated for any of several (e.g. RU,UA)"
+// @Param        country                 query     string  false  "Filter by any country in the predicted distribution"
+// @Param        min_gender_probability  query     number  false  "Minimum gender probability"
+// @Param        min_age_count           query     int     false  "Minimum agify sample count"
+// @Param        min_gender_count        query     int     false  "Minimum genderize sample count"
+// @Param        enrichment_status       query     string  false  "Filter by enrichment status (pending, enriched, partial, failed)"
+// @Param        created_after           query     string  false  "Created at or after (RFC 3339 or YYYY-MM-DD)"
+// @Param        created_before          query     string  false  "Created at or before an RFC 3339 timestamp, or on or before a YYYY-MM-DD date"
+// @Success      200                     {object}  dto.RefreshReportResponse
+// @Failure      400                     {object}  dto.ErrorResponse
+// @Failure      500                     {object}  dto.ErrorResponse
+// @Router       /api/v1/persons/enrich [post]
+func (h *PersonHandler) ReenrichMany(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 || limit > maxReenrichLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 1000"})
+		return
+	}
+
+	filter, err := buildFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.useCase.ReenrichMany(c.Request.Context(), filter, limit)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toRefreshReportResponse(report))
+}
+
+// GetEnrichmentHistory godoc
+// @Summary      Get enrichment history
+// @Description  Get the provider calls made while enriching a person, newest first, with raw responses
+// @Tags         enrichment
+// @Produce      json
+// @Param        id     path      int  true   "Person ID"
+// @Param        limit  query     int  false  "Maximum number of entries (default 100, max 1000)"
+// @Success      200    {object}  dto.EnrichmentHistoryResponse
+// @Failure      400    {object}  dto.ErrorResponse
+// @Failure      404    {object}  dto.ErrorResponse
+// @Failure      500    {object}  dto.ErrorResponse
+// @Router       /api/v1/persons/{id}/enrichment-history [get]
+func (h *PersonHandler) GetEnrichmentHistory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 || limit > maxHistoryLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 1000"})
+		return
+	}
+
+	if _, err := h.useCase.GetByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries, err := h.useCase.GetEnrichmentHistory(c.Request.Context(), id, limit)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	response := dto.EnrichmentHistoryResponse{
+		PersonID: id,
+		Entries:  make([]dto.EnrichmentLogEntryResponse, len(entries)),
+	}
+	for i, entry := range entries {
+		response.Entries[i] = dto.EnrichmentLogEntryResponse{
+			ID:           entry.ID,
+			Provider:     entry.Provider,
+			RequestURL:   entry.RequestURL,
+			StatusCode:   entry.StatusCode,
+			LatencyMs:    entry.LatencyMs,
+			ResponseBody: entry.ResponseBody,
+			Error:        entry.Error,
+			CreatedAt:    entry.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// LastRefresh godoc
+// @Summary      Get last scheduled refresh
+// @Description  Get the report of the last scheduled refresh of stale records
+// @Tags         admin
+// @Produce      json
+// @Success      200  {object}  dto.RefreshReportResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Router       /api/v1/admin/refresh [get]
+func (h *PersonHandler) LastRefresh(c *gin.Context) {
+	report := h.useCase.LastRefreshReport()
+	if report == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no refresh has run yet"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toRefreshReportResponse(report))
+}
+
+func toEnrichmentReportResponse(report entity.EnrichmentReport) dto.EnrichmentReportResponse {
+	changes := make([]dto.FieldChangeResponse, len(report.Changes))
+	for i, change := range report.Changes {
+		changes[i] = dto.FieldChangeResponse{
+			Field: change.Field,
+			Old:   change.Old,
+			New:   change.New,
+		}
+	}
+
+	return dto.EnrichmentReportResponse{
+		PersonID:      report.PersonID,
+		Changes:       changes,
+		MissingFields: report.MissingFields,
+		Error:         report.Error,
+	}
+}
+
+func toRefreshReportResponse(report *entity.RefreshReport) dto.RefreshReportResponse {
+	persons := make([]dto.EnrichmentReportResponse, len(report.Persons))
+	for i, person := range report.Persons {
+		persons[i] = toEnrichmentReportResponse(person)
+	}
+
+	return dto.RefreshReportResponse{
+		StartedAt:  report.StartedAt,
+		FinishedAt: report.FinishedAt,
+		Processed:  report.Processed,
+		Changed:    report.Changed,
+		Failed:     report.Failed,
+		Persons:    persons,
+	}
+}
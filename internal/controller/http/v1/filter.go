@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+
+	"Name_IQ_Finder/internal/controller/http/dto"
+	"Name_IQ_Finder/internal/entity"
+)
+
+const (
+	maxFilterValues = 50
+	maxQueryLength  = 100
+
+	searchModeExact = "exact"
+	searchModeFuzzy = "fuzzy"
+)
+
+func buildFilter(c *gin.Context) (*entity.Filter, error) {
+	minGenderProbability, err := optionalFloat(c, "min_gender_probability")
+	if err != nil {
+		return nil, err
+	}
+	minAgeCount, err := optionalInt(c, "min_age_count")
+	if err != nil {
+		return nil, err
+	}
+	minGenderCount, err := optionalInt(c, "min_gender_count")
+	if err != nil {
+		return nil, err
+	}
+
+	ageGte, err := optionalAge(c, "age_gte")
+	if err != nil {
+		return nil, err
+	}
+	ageLte, err := optionalAge(c, "age_lte")
+	if err != nil {
+		return nil, err
+	}
+	if ageGte != nil && ageLte != nil && *ageGte > *ageLte {
+		return nil, fmt.Errorf("invalid age range: age_gte (%d) is greater than age_lte (%d)", *ageGte, *ageLte)
+	}
+
+	gender := c.Query("gender")
+	if gender != "" && gender != "male" && gender != "female" {
+		return nil, fmt.Errorf("invalid gender: %q must be male or female", gender)
+	}
+
+	nationalities, err := optionalCountries(c, "nationality")
+	if err != nil {
+		return nil, err
+	}
+
+	country, err := optionalCountry(c, "country")
+	if err != nil {
+		return nil, err
+	}
+
+	status := entity.EnrichmentStatus(c.Query("enrichment_status"))
+	if status != "" && !status.Valid() {
+		return nil, fmt.Errorf("invalid enrichment_status: %q must be pending, enriched, partial or failed", status)
+	}
+
+	createdAfter, _, err := optionalTime(c, "created_after")
+	if err != nil {
+		return nil, err
+	}
+	createdBefore, beforeDate, err := optionalTime(c, "created_before")
+	if err != nil {
+		return nil, err
+	}
+	if createdAfter != nil && createdBefore != nil && createdAfter.After(*createdBefore) {
+		return nil, errors.New("invalid created range: created_after is later than created_before")
+	}
+
+	var createdBeforeDay *time.Time
+	if beforeDate {
+		nextDay := createdBefore.AddDate(0, 0, 1)
+		createdBefore, createdBeforeDay = nil, &nextDay
+	}
+
+	text := strings.TrimSpace(c.Query("q"))
+	if len([]rune(text)) > maxQueryLength {
+		return nil, fmt.Errorf("invalid q: must be at most %d characters", maxQueryLength)
+	}
+
+	switch c.Query("mode") {
+	case "", searchModeExact:
+	case searchModeFuzzy:
+		text = ""
+	default:
+		return nil, fmt.Errorf("invalid mode: %q must be exact or fuzzy", c.Query("mode"))
+	}
+
+	return dto.NewFilterBuilder().
+		WithText(text).
+		WithName(c.Query("name")).
+		WithSurname(c.Query("surname")).
+		WithPatronymic(c.Query("patronymic")).
+		WithAgeRange(ageGte, ageLte).
+		WithGender(gender).
+		WithNationalities(nationalities).
+		WithCountry(country).
+		WithMinGenderProbability(minGenderProbability).
+		WithMinAgeCount(minAgeCount).
+		WithMinGenderCount(minGenderCount).
+		WithEnrichmentStatus(string(status)).
+		WithCreatedRange(createdAfter, createdBefore).
+		WithCreatedBefore(createdBeforeDay).
+		Build()
+}
+
+func optionalAge(c *gin.Context, key string) (*int, error) {
+	value, err := optionalInt(c, key)
+	if err != nil {
+		return nil, err
+	}
+	if value != nil && *value < 0 {
+		return nil, fmt.Errorf("invalid %s: %d must not be negative", key, *value)
+	}
+
+	return value, nil
+}
+
+func optionalCountries(c *gin.Context, key string) ([]string, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	if len(parts) > maxFilterValues {
+		return nil, fmt.Errorf("invalid %s: at most %d values are allowed", key, maxFilterValues)
+	}
+
+	countries := make([]string, 0, len(parts))
+	for _, part := range parts {
+		country := strings.ToUpper(strings.TrimSpace(part))
+		if len(country) != 2 || !isLetters(country) {
+			return nil, fmt.Errorf("invalid %s: %q is not an ISO 3166-1 alpha-2 code", key, part)
+		}
+		countries = append(countries, country)
+	}
+
+	return countries, nil
+}
+
+func optionalCountry(c *gin.Context, key string) (string, error) {
+	raw := strings.TrimSpace(c.Query(key))
+	if raw == "" {
+		return "", nil
+	}
+
+	country := strings.ToUpper(raw)
+	if len(country) != 2 || !isLetters(country) {
+		return "", fmt.Errorf("invalid %s: %q is not an ISO 3166-1 alpha-2 code", key, raw)
+	}
+
+	return country, nil
+}
+
+func optionalTime(c *gin.Context, key string) (*time.Time, bool, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, false, nil
+	}
+
+	if value, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &value, false, nil
+	}
+	if value, err := time.Parse(time.DateOnly, raw); err == nil {
+		return &value, true, nil
+	}
+
+	return nil, false, fmt.Errorf("invalid %s: %q is not an RFC 3339 timestamp or YYYY-MM-DD date", key, raw)
+}
+
+func isLetters(value string) bool {
+	for _, r := range value {
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+			return false
+		}
+	}
+
+	return true
+}
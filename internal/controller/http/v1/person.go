@@ -1,8 +1,12 @@
 package v1
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -22,14 +26,18 @@ func NewPersonHandler(useCase entity.PersonUseCase) *PersonHandler {
 
 // Create godoc
 // @Summary      Create person
-// @Description  Create a new person with enriched data
+// @Description  Create a new person with enriched data. Fields no provider could predict are left null and listed in missing_fields. In async mode the person is stored immediately and enriched in the background
 // @Tags         persons
 // @Accept       json
 // @Produce      json
 // @Param        request  body      dto.CreatePersonRequest  true  "Person data"
 // @Success      201      {object}  dto.PersonResponse
+// @Success      202      {object}  dto.CreatePersonAcceptedResponse
 // @Failure      400      {object}  dto.ErrorResponse
+// @Failure      429      {object}  dto.ErrorResponse
 // @Failure      500      {object}  dto.ErrorResponse
+// @Failure      503      {object}  dto.ErrorResponse
+// @Failure      504      {object}  dto.ErrorResponse
 // @Router       /api/v1/persons [post]
 func (h *PersonHandler) Create(c *gin.Context) {
 	var req dto.CreatePersonRequest
@@ -38,15 +46,75 @@ func (h *PersonHandler) Create(c *gin.Context) {
 		return
 	}
 
-	person, err := h.useCase.Create(req.Name, req.Surname, req.Patronymic)
+	person, err := h.useCase.Create(c.Request.Context(), req.Name, req.Surname, req.Patronymic, req.Country)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		var quotaErr *entity.QuotaError
+		if errors.As(err, &quotaErr) {
+			c.Header("Retry-After", strconv.Itoa(int(time.Until(quotaErr.ResetAt).Seconds())+1))
+		}
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	if person.EnrichmentStatus == entity.EnrichmentPending {
+		statusURL := fmt.Sprintf("/api/v1/persons/%d/enrichment", person.ID)
+		c.Header("Location", statusURL)
+		c.JSON(http.StatusAccepted, dto.CreatePersonAcceptedResponse{
+			Person:    toPersonResponse(person),
+			StatusURL: statusURL,
+		})
 		return
 	}
 
 	c.JSON(http.StatusCreated, toPersonResponse(person))
 }
 
+// CreateBatch godoc
+// @Summary      Create persons in bulk
+// @Description  Create several persons at once, enriching their names in batches
+// @Tags         persons
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreatePersonBatchRequest  true  "Persons data"
+// @Success      200      {object}  dto.CreatePersonBatchResponse
+// @Failure      400      {object}  dto.ErrorResponse
+// @Router       /api/v1/persons/batch [post]
+func (h *PersonHandler) CreateBatch(c *gin.Context) {
+	var req dto.CreatePersonBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	people := make([]*entity.Person, len(req.Persons))
+	for i, p := range req.Persons {
+		people[i] = &entity.Person{
+			Name:        p.Name,
+			Surname:     p.Surname,
+			Patronymic:  p.Patronymic,
+			CountryHint: p.Country,
+		}
+	}
+
+	response := dto.CreatePersonBatchResponse{
+		Results: make([]dto.BatchItemResponse, len(people)),
+	}
+	for i, result := range h.useCase.CreateBatch(c.Request.Context(), people) {
+		response.Results[i].Index = i
+		if result.Err != nil {
+			response.Results[i].Error = result.Err.Error()
+			response.Failed++
+			continue
+		}
+
+		personResponse := toPersonResponse(result.Person)
+		response.Results[i].Person = &personResponse
+		response.Created++
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetByID godoc
 // @Summary      Get person by ID
 // @Description  Get a person by ID
@@ -65,7 +133,7 @@ func (h *PersonHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	person, err := h.useCase.GetByID(id)
+	person, err := h.useCase.GetByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -74,32 +142,86 @@ func (h *PersonHandler) GetByID(c *gin.Context) {
 	c.JSON(http.StatusOK, toPersonResponse(person))
 }
 
+// GetEnrichmentStatus godoc
+// @Summary      Get enrichment status
+// @Description  Get the state of the latest enrichment job of a person
+// @Tags         persons
+// @Produce      json
+// @Param        id   path      int  true  "Person ID"
+// @Success      200  {object}  dto.EnrichmentJobResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Router       /api/v1/persons/{id}/enrichment [get]
+func (h *PersonHandler) GetEnrichmentStatus(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	job, err := h.useCase.GetEnrichmentJob(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.EnrichmentJobResponse{
+		PersonID:  job.PersonID,
+		Status:    string(job.Status),
+		Attempts:  job.Attempts,
+		LastError: job.LastError,
+		RunAt:     job.RunAt,
+		UpdatedAt: job.UpdatedAt,
+	})
+}
+
 // GetAll godoc
 // @Summary      Get all persons
-// @Description  Get all persons with optional filtering and pagination
+// @Description  Get all persons with optional filtering and pagination.
+// @Description  With mode=fuzzy, q is matched by trigram similarity and phonetic key against name, surname and patronymic, and results are ranked by score.
 // @Tags         persons
 // @Produce      json
-// @Param        page        query     int     false  "Page number"
-// @Param        limit       query     int     false  "Items per page"
-// @Param        name        query     string  false  "Filter by name"
-// @Param        surname     query     string  false  "Filter by surname"
-// @Param        nationality query     string  false  "Filter by nationality"
-// @Success      200         {object}  dto.PersonListResponse
-// @Failure      500         {object}  dto.ErrorResponse
+// @Param        page                    query     int     false  "Page number"
+// @Param        limit                   query     int     false  "Items per page"
+// @Param        q                       query     string  false  "Case-insensitive substring of name, resolved name, surname or patronymic"
+// @Param        mode                    query     string  false  "How q is matched (default exact)"  Enums(exact, fuzzy)
+// @Param        min_score               query     number  false  "Minimum similarity score in fuzzy mode, 0 to 1 (default 0.3)"
+// @Param        name                    query     string  false  "Filter by name"
+// @Param        surname                 query     string  false  "Filter by surname"
+// @Param        patronymic              query     string  false  "Filter by patronymic"
+// @Param        age_gte                 query     int     false  "Minimum age (inclusive)"
+// @Param        age_lte                 query     int     false  "Maximum age (inclusive)"
+// @Param        gender                  query     string  false  "Filter by gender"  Enums(male, female)
+// @Param        nationality             query     string  false  "Filter by nationality, comma-separated for any of several (e.g. RU,UA)"
+// @Param        country                 query     string  false  "Filter by any country in the predicted distribution"
+// @Param        min_gender_probability  query     number  false  "Minimum gender probability"
+// @Param        min_age_count           query     int     false  "Minimum agify sample count"
+// @Param        min_gender_count        query     int     false  "Minimum genderize sample count"
+// @Param        enrichment_status       query     string  false  "Filter by enrichment status (pending, enriched, partial, failed)"
+// @Param        created_after           query     string  false  "Created at or after (RFC 3339 or YYYY-MM-DD)"
+// @Param        created_before          query     string  false  "Created at or before an RFC 3339 timestamp, or on or before a YYYY-MM-DD date"
+// @Success      200                     {object}  dto.PersonListResponse
+// @Failure      400                     {object}  dto.ErrorResponse
+// @Failure      500                     {object}  dto.ErrorResponse
 // @Router       /api/v1/persons [get]
 func (h *PersonHandler) GetAll(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
-	filter := dto.NewFilterBuilder().
-		WithName(c.Query("name")).
-		WithSurname(c.Query("surname")).
-		WithNationality(c.Query("nationality")).
-		Build()
+	filter, err := buildFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if c.Query("mode") == searchModeFuzzy {
+		h.fuzzySearch(c, filter, page, limit)
+		return
+	}
 
-	persons, total, err := h.useCase.GetAll(filter, page, limit)
+	persons, total, err := h.useCase.GetAll(c.Request.Context(), filter, page, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -136,7 +258,7 @@ func (h *PersonHandler) Update(c *gin.Context) {
 		return
 	}
 
-	person, err := h.useCase.GetByID(id)
+	person, err := h.useCase.GetByID(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		return
@@ -158,17 +280,24 @@ func (h *PersonHandler) Update(c *gin.Context) {
 		person.Patronymic = req.Patronymic
 	}
 	if req.Age != nil {
-		person.Age = *req.Age
+		person.Age = req.Age
+		person.AgeCount = 0
+		person.AgeSource = entity.SourceManual
+		person.AgeConfidence = 0
 	}
 	if req.Gender != "" {
-		person.Gender = req.Gender
+		person.Gender = &req.Gender
+		person.GenderProbability = 0
+		person.GenderCount = 0
+		person.GenderSource = entity.SourceManual
 	}
 	if req.Nationality != "" {
-		person.Nationality = req.Nationality
+		person.Nationality = &req.Nationality
+		person.NationalitySource = entity.SourceManual
 	}
 
-	if err := h.useCase.Update(person); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.useCase.Update(c.Request.Context(), person); err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -192,24 +321,113 @@ func (h *PersonHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.useCase.Delete(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.useCase.Delete(c.Request.Context(), id); err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+func toCountryResponses(countries []entity.CountryProbability) []dto.CountryProbabilityResponse {
+	responses := make([]dto.CountryProbabilityResponse, len(countries))
+	for i, country := range countries {
+		responses[i] = dto.CountryProbabilityResponse{
+			CountryID:   country.CountryID,
+			Probability: country.Probability,
+		}
+	}
+
+	return responses
+}
+
 func toPersonResponse(person *entity.Person) dto.PersonResponse {
+	var nameCountries, surnameCountries []dto.CountryProbabilityResponse
+	if person.NameCountries != nil {
+		nameCountries = toCountryResponses(person.NameCountries)
+	}
+	if person.SurnameCountries != nil {
+		surnameCountries = toCountryResponses(person.SurnameCountries)
+	}
+
 	return dto.PersonResponse{
-		ID:          person.ID,
-		Name:        person.Name,
-		Surname:     person.Surname,
-		Patronymic:  person.Patronymic,
-		Age:         person.Age,
-		Gender:      person.Gender,
-		Nationality: person.Nationality,
-		CreatedAt:   person.CreatedAt,
-		UpdatedAt:   person.UpdatedAt,
+		ID:                  person.ID,
+		Name:                person.Name,
+		ResolvedName:        person.ResolvedName,
+		CanonicalName:       person.CanonicalName,
+		Surname:             person.Surname,
+		Patronymic:          person.Patronymic,
+		Age:                 person.Age,
+		AgeCount:            person.AgeCount,
+		AgeSource:           person.AgeSource,
+		AgeConfidence:       person.AgeConfidence,
+		Gender:              person.Gender,
+		GenderProbability:   person.GenderProbability,
+		GenderCount:         person.GenderCount,
+		GenderSource:        person.GenderSource,
+		Nationality:         person.Nationality,
+		Countries:           toCountryResponses(person.Countries),
+		NameCountries:       nameCountries,
+		SurnameCountries:    surnameCountries,
+		NationalitySource:   person.NationalitySource,
+		CountryHint:         person.CountryHint,
+		LocalizationCountry: person.LocalizationCountry,
+		EnrichmentStatus:    string(person.EnrichmentStatus),
+		NeedsReview:         person.NeedsReview,
+		Review:              toReviewResponse(person.Review),
+		MissingFields:       person.MissingFields(),
+		CreatedAt:           person.CreatedAt,
+		UpdatedAt:           person.UpdatedAt,
 	}
 }
+
+func errorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, entity.ErrQuotaExhausted):
+		return http.StatusTooManyRequests
+	case errors.Is(err, entity.ErrProviderUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, entity.ErrDiminutiveNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrDiminutiveExists):
+		return http.StatusConflict
+	case errors.Is(err, entity.ErrNoPendingReview):
+		return http.StatusConflict
+	case errors.Is(err, entity.ErrInvalidReviewValue):
+		return http.StatusBadRequest
+	case errors.Is(err, entity.ErrInvalidFilter):
+		return http.StatusBadRequest
+	default:
+		return fallback
+	}
+}
+
+func optionalFloat(c *gin.Context, key string) (*float64, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %q is not a number", key, raw)
+	}
+
+	return &value, nil
+}
+
+func optionalInt(c *gin.Context, key string) (*int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %q is not an integer", key, raw)
+	}
+
+	return &value, nil
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"Name_IQ_Finder/internal/controller/http/dto"
+	"Name_IQ_Finder/internal/entity"
+)
+
+type ProviderHandler struct {
+	health entity.ProviderHealth
+}
+
+func NewProviderHandler(health entity.ProviderHealth) *ProviderHandler {
+	return &ProviderHandler{
+		health: health,
+	}
+}
+
+// Statuses godoc
+// @Summary      Get provider statuses
+// @Description  Get circuit breaker state of every enrichment provider
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}  dto.ProviderStatusResponse
+// @Router       /api/v1/admin/providers [get]
+func (h *ProviderHandler) Statuses(c *gin.Context) {
+	statuses := h.health.ProviderStatuses()
+
+	response := make([]dto.ProviderStatusResponse, len(statuses))
+	for i, status := range statuses {
+		response[i] = dto.ProviderStatusResponse{
+			Provider: status.Provider,
+			State:    status.State,
+			Failures: status.Failures,
+			OpenedAt: status.OpenedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Quota godoc
+// @Summary      Get provider quota usage
+// @Description  Get today's request count, daily limit and the remaining budget reported by every enrichment provider
+// @Tags         admin
+// @Produce      json
+// @Success      200  {array}   dto.QuotaUsageResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/v1/admin/quota [get]
+func (h *ProviderHandler) Quota(c *gin.Context) {
+	usages, err := h.health.QuotaUsage(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]dto.QuotaUsageResponse, len(usages))
+	for i, usage := range usages {
+		response[i] = dto.QuotaUsageResponse{
+			Provider:  usage.Provider,
+			Day:       usage.Day,
+			Used:      usage.Used,
+			Limit:     usage.Limit,
+			Remaining: usage.Remaining,
+			ResetAt:   usage.ResetAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
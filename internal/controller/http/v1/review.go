@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"Name_IQ_Finder/internal/controller/http/dto"
+	"Name_IQ_Finder/internal/entity"
+)
+
+// GetReviewQueue godoc
+// @Summary      Get review queue
+// @Description  Get persons with predictions below the confidence thresholds awaiting review
+// @Tags         review
+// @Produce      json
+// @Param        page   query     int  false  "Page number"
+// @Param        limit  query     int  false  "Items per page"
+// @Success      200    {object}  dto.PersonListResponse
+// @Failure      500    {object}  dto.ErrorResponse
+// @Router       /api/v1/review-queue [get]
+func (h *PersonHandler) GetReviewQueue(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	persons, total, err := h.useCase.GetReviewQueue(c.Request.Context(), page, limit)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	personResponses := make([]dto.PersonResponse, len(persons))
+	for i, person := range persons {
+		personResponses[i] = toPersonResponse(person)
+	}
+
+	c.JSON(http.StatusOK, dto.PersonListResponse{
+		Data:       personResponses,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+	})
+}
+
+// ResolveReview godoc
+// @Summary      Resolve review
+// @Description  Accept the proposed value of a field or override it with a manual one
+// @Tags         review
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                       true  "Person ID"
+// @Param        request  body      dto.ResolveReviewRequest  true  "Decision"
+// @Success      200      {object}  dto.PersonResponse
+// @Failure      400      {object}  dto.ErrorResponse
+// @Failure      404      {object}  dto.ErrorResponse
+// @Failure      409      {object}  dto.ErrorResponse
+// @Failure      500      {object}  dto.ErrorResponse
+// @Router       /api/v1/review-queue/{id} [post]
+func (h *PersonHandler) ResolveReview(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.ResolveReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := h.useCase.GetByID(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	person, err := h.useCase.ResolveReview(c.Request.Context(), id, req.Field, req.Action, req.Value)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toPersonResponse(person))
+}
+
+func toReviewResponse(review *entity.PendingReview) *dto.ReviewResponse {
+	if review == nil {
+		return nil
+	}
+
+	var response dto.ReviewResponse
+	if review.Age != nil {
+		response.Age = &dto.ProposedValueResponse{
+			Value:  review.Age.Age,
+			Count:  review.Age.Count,
+			Source: review.Age.Source,
+		}
+	}
+	if review.Gender != nil {
+		response.Gender = &dto.ProposedValueResponse{
+			Value:       review.Gender.Gender,
+			Probability: review.Gender.Probability,
+			Count:       review.Gender.Count,
+			Source:      review.Gender.Source,
+		}
+	}
+	if review.Nationality != nil && len(review.Nationality.Countries) > 0 {
+		response.Nationality = &dto.ProposedValueResponse{
+			Value:       review.Nationality.Top(),
+			Probability: review.Nationality.Countries[0].Probability,
+			Count:       review.Nationality.Count,
+			Source:      review.Nationality.Source,
+		}
+	}
+
+	return &response
+}
@@ -8,19 +8,66 @@ import (
 	"Name_IQ_Finder/internal/entity"
 )
 
-func RegisterRoutes(router *gin.Engine, useCase entity.PersonUseCase) {
+type Timeouts struct {
+	Request gin.HandlerFunc
+	Bulk    gin.HandlerFunc
+}
+
+func RegisterRoutes(router *gin.Engine, useCase entity.PersonUseCase, diminutives entity.DiminutiveUseCase, cache entity.EnrichmentCache, health entity.ProviderHealth, timeouts Timeouts) {
 	handler := NewPersonHandler(useCase)
 
-	v1 := router.Group("/api/v1")
+	bulk := router.Group("/api/v1/persons", timeouts.Bulk)
+	{
+		bulk.POST("/batch", handler.CreateBatch)
+		bulk.POST("/enrich", handler.ReenrichMany)
+	}
+
+	v1 := router.Group("/api/v1", timeouts.Request)
 	{
 		persons := v1.Group("/persons")
 		{
 			persons.POST("", handler.Create)
+			persons.POST("/search", handler.Search)
 			persons.GET("", handler.GetAll)
+			persons.GET("/suggest", handler.Suggest)
 			persons.GET("/:id", handler.GetByID)
+			persons.GET("/:id/enrichment", handler.GetEnrichmentStatus)
+			persons.GET("/:id/enrichment-history", handler.GetEnrichmentHistory)
+			persons.POST("/:id/enrich", handler.Reenrich)
 			persons.PUT("/:id", handler.Update)
 			persons.DELETE("/:id", handler.Delete)
 		}
+
+		review := v1.Group("/review-queue")
+		{
+			review.GET("", handler.GetReviewQueue)
+			review.POST("/:id", handler.ResolveReview)
+		}
+
+		admin := v1.Group("/admin")
+		{
+			admin.GET("/refresh", handler.LastRefresh)
+
+			diminutiveHandler := NewDiminutiveHandler(diminutives)
+			admin.GET("/diminutives", diminutiveHandler.List)
+			admin.POST("/diminutives", diminutiveHandler.Create)
+			admin.GET("/diminutives/:diminutive", diminutiveHandler.Get)
+			admin.PUT("/diminutives/:diminutive", diminutiveHandler.Update)
+			admin.DELETE("/diminutives/:diminutive", diminutiveHandler.Delete)
+
+			if cache != nil {
+				cacheHandler := NewCacheHandler(cache)
+				admin.GET("/cache", cacheHandler.Stats)
+				admin.DELETE("/cache", cacheHandler.Purge)
+				admin.DELETE("/cache/:name", cacheHandler.Invalidate)
+			}
+
+			if health != nil {
+				providerHandler := NewProviderHandler(health)
+				admin.GET("/providers", providerHandler.Statuses)
+				admin.GET("/quota", providerHandler.Quota)
+			}
+		}
 	}
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"Name_IQ_Finder/internal/controller/http/dto"
+	"Name_IQ_Finder/internal/entity"
+)
+
+const maxSearchBodySize = 64 << 10
+
+// Search godoc
+// @Summary      Search persons
+// @Description  Search persons with a JSON query tree of and/or/not nodes over person fields, plus sort and pagination.
+// @Description  A condition is {"field": "age", "op": "gt", "value": 40}; operators are eq, ne, in, gt, gte, lt, lte, range, prefix, contains, is_null and not_null.
+// @Description  Queries deeper than 6 levels or with more than 100 nodes and values are rejected, as are bodies over 64 KiB.
+// @Tags         persons
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.SearchRequest  true  "Search query"
+// @Success      200      {object}  dto.PersonListResponse
+// @Failure      400      {object}  dto.ErrorResponse
+// @Failure      413      {object}  dto.ErrorResponse
+// @Failure      500      {object}  dto.ErrorResponse
+// @Router       /api/v1/persons/search [post]
+func (h *PersonHandler) Search(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSearchBodySize)
+
+	var req dto.SearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("search request exceeds %d bytes", tooLarge.Limit)})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query, err := req.ToQuery()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	persons, total, err := h.useCase.Search(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	personResponses := make([]dto.PersonResponse, len(persons))
+	for i, person := range persons {
+		personResponses[i] = toPersonResponse(person)
+	}
+
+	c.JSON(http.StatusOK, dto.PersonListResponse{
+		Data:       personResponses,
+		TotalCount: total,
+		Page:       query.Page,
+		Limit:      query.Limit,
+	})
+}
+
+func (h *PersonHandler) fuzzySearch(c *gin.Context, filter *entity.Filter, page, limit int) {
+	minScore, err := optionalFloat(c, "min_score")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := &entity.FuzzyQuery{
+		Text:     strings.TrimSpace(c.Query("q")),
+		MinScore: entity.DefaultFuzzyMinScore,
+	}
+	if minScore != nil {
+		if *minScore < 0 || *minScore > 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid min_score: must be between 0 and 1"})
+			return
+		}
+		query.MinScore = *minScore
+	}
+
+	results, total, err := h.useCase.FuzzySearch(c.Request.Context(), query, filter, page, limit)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	personResponses := make([]dto.PersonResponse, len(results))
+	for i, result := range results {
+		personResponses[i] = toPersonResponse(result.Person)
+		personResponses[i].Score = &result.Score
+	}
+
+	c.JSON(http.StatusOK, dto.PersonListResponse{
+		Data:       personResponses,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+	})
+}
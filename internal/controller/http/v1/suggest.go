@@ -0,0 +1,47 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"Name_IQ_Finder/internal/controller/http/dto"
+	"Name_IQ_Finder/internal/entity"
+)
+
+// Suggest godoc
+// @Summary      Suggest names
+// @Description  Get distinct names, surnames or patronymics starting with a prefix, case-insensitively, ordered by how often they occur
+// @Tags         persons
+// @Produce      json
+// @Param        field   query     string  true   "Field to complete"  Enums(name, surname, patronymic)
+// @Param        prefix  query     string  true   "Prefix, at least 2 characters"
+// @Param        limit   query     int     false  "Maximum number of suggestions (default 10, max 50)"
+// @Success      200     {array}   dto.SuggestionResponse
+// @Failure      400     {object}  dto.ErrorResponse
+// @Failure      500     {object}  dto.ErrorResponse
+// @Router       /api/v1/persons/suggest [get]
+func (h *PersonHandler) Suggest(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(entity.DefaultSuggestLimit)))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit: must be a positive integer"})
+		return
+	}
+
+	suggestions, err := h.useCase.Suggest(c.Request.Context(), entity.FilterField(c.Query("field")), c.Query("prefix"), limit)
+	if err != nil {
+		c.JSON(errorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]dto.SuggestionResponse, len(suggestions))
+	for i, suggestion := range suggestions {
+		response[i] = dto.SuggestionResponse{
+			Value: suggestion.Value,
+			Count: suggestion.Count,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "context"
+
+type CacheStats struct {
+	Hits       int64 `json:"hits"`
+	Misses     int64 `json:"misses"`
+	MemoryHits int64 `json:"memory_hits"`
+	SharedHits int64 `json:"shared_hits"`
+	Entries    int   `json:"entries"`
+}
+
+type EnrichmentCache interface {
+	Invalidate(ctx context.Context, name string) error
+	Purge(ctx context.Context) error
+	Stats() CacheStats
+}
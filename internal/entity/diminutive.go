@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrDiminutiveNotFound = errors.New("diminutive not found")
+	ErrDiminutiveExists   = errors.New("diminutive already exists")
+)
+
+type Diminutive struct {
+	Diminutive string `json:"diminutive"`
+	Canonical  string `json:"canonical"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+type NameResolver interface {
+	Resolve(name string) (string, bool)
+}
+
+type DiminutiveRepository interface {
+	List(ctx context.Context) ([]*Diminutive, error)
+	Get(ctx context.Context, diminutive string) (*Diminutive, error)
+	Create(ctx context.Context, diminutive *Diminutive) error
+	Update(ctx context.Context, diminutive *Diminutive) error
+	Delete(ctx context.Context, diminutive string) error
+}
+
+type DiminutiveUseCase interface {
+	NameResolver
+	List(ctx context.Context) ([]*Diminutive, error)
+	Get(ctx context.Context, diminutive string) (*Diminutive, error)
+	Create(ctx context.Context, diminutive, canonical string) (*Diminutive, error)
+	Update(ctx context.Context, diminutive, canonical string) (*Diminutive, error)
+	Delete(ctx context.Context, diminutive string) error
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrNoPrediction = errors.New("provider returned no prediction")
+
+const (
+	FieldAge         = "age"
+	FieldGender      = "gender"
+	FieldNationality = "nationality"
+)
+
+const (
+	SourceProvider = "provider"
+	SourceRules    = "rules"
+	SourceManual   = "manual"
+	SourceDataset  = "dataset"
+	SourceEnsemble = "ensemble"
+	SourceSurname  = "surname"
+	SourceCombined = "combined"
+)
+
+type CountryProbability struct {
+	CountryID   string  `json:"country_id"`
+	Probability float64 `json:"probability"`
+}
+
+type AgePrediction struct {
+	Age        int     `json:"age"`
+	Count      int     `json:"count"`
+	Confidence float64 `json:"confidence,omitempty"`
+	Source     string  `json:"source,omitempty"`
+}
+
+type GenderPrediction struct {
+	Gender      string  `json:"gender"`
+	Probability float64 `json:"probability"`
+	Count       int     `json:"count"`
+	Source      string  `json:"source,omitempty"`
+}
+
+type NationalityPrediction struct {
+	Countries        []CountryProbability `json:"countries"`
+	NameCountries    []CountryProbability `json:"name_countries,omitempty"`
+	SurnameCountries []CountryProbability `json:"surname_countries,omitempty"`
+	Count            int                  `json:"count"`
+	Source           string               `json:"source,omitempty"`
+}
+
+func (p NationalityPrediction) Top() string {
+	if len(p.Countries) == 0 {
+		return ""
+	}
+	return p.Countries[0].CountryID
+}
+
+type Enrichment struct {
+	Age         *AgePrediction
+	Gender      *GenderPrediction
+	Nationality *NationalityPrediction
+	Review      *PendingReview
+	Errors      map[string]error
+}
+
+func (e *Enrichment) SetError(field string, err error) {
+	if e.Errors == nil {
+		e.Errors = make(map[string]error)
+	}
+	e.Errors[field] = err
+}
+
+func (e *Enrichment) Without(fields ...string) *Enrichment {
+	if len(fields) == 0 {
+		return e
+	}
+
+	result := *e
+	result.Errors = nil
+	for field, err := range e.Errors {
+		if !slices.Contains(fields, field) {
+			result.SetError(field, err)
+		}
+	}
+
+	for _, field := range fields {
+		switch field {
+		case FieldAge:
+			result.Age = nil
+		case FieldGender:
+			result.Gender = nil
+		case FieldNationality:
+			result.Nationality = nil
+		}
+	}
+
+	return &result
+}
+
+func (e *Enrichment) Failed() bool {
+	if e.Age != nil || e.Gender != nil || e.Nationality != nil || e.Review != nil {
+		return false
+	}
+	return e.Err() != nil
+}
+
+func (e *Enrichment) Complete() bool {
+	return e.Age != nil && e.Gender != nil && e.Nationality != nil
+}
+
+func (e *Enrichment) QuotaErr() *QuotaError {
+	for _, field := range []string{FieldAge, FieldGender, FieldNationality} {
+		var quotaErr *QuotaError
+		if errors.As(e.Errors[field], &quotaErr) {
+			return quotaErr
+		}
+	}
+
+	return nil
+}
+
+func (e *Enrichment) Err() error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, field := range []string{FieldAge, FieldGender, FieldNationality} {
+		if err, ok := e.Errors[field]; ok && !errors.Is(err, ErrNoPrediction) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
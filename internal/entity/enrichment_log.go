@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+type EnrichmentLogEntry struct {
+	ID           int64    `json:"id"`
+	PersonID     int64    `json:"person_id"`
+	Provider     string   `json:"provider"`
+	RequestURL   string   `json:"request_url"`
+	Names        []string `json:"-"`
+	StatusCode   int      `json:"status_code"`
+	LatencyMs    int64    `json:"latency_ms"`
+	ResponseBody string   `json:"response_body"`
+	Error        string   `json:"error,omitempty"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+type EnrichmentLogRepository interface {
+	Save(ctx context.Context, entries []EnrichmentLogEntry) error
+	ListByPersonID(ctx context.Context, personID int64, limit int) ([]*EnrichmentLogEntry, error)
+}
+
+type CallRecorder struct {
+	mu      sync.Mutex
+	entries []EnrichmentLogEntry
+}
+
+type callRecorderKey struct{}
+
+func WithCallRecorder(ctx context.Context) (context.Context, *CallRecorder) {
+	recorder := &CallRecorder{}
+	return context.WithValue(ctx, callRecorderKey{}, recorder), recorder
+}
+
+func RecordCall(ctx context.Context, entry EnrichmentLogEntry) {
+	recorder, ok := ctx.Value(callRecorderKey{}).(*CallRecorder)
+	if !ok {
+		return
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.entries = append(recorder.entries, entry)
+}
+
+func (r *CallRecorder) EntriesFor(names ...string) []EnrichmentLogEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entries []EnrichmentLogEntry
+	for _, entry := range r.entries {
+		if slices.ContainsFunc(entry.Names, func(name string) bool {
+			return name != "" && slices.Contains(names, name)
+		}) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+func (r *CallRecorder) Entries() []EnrichmentLogEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.entries)
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEnrichmentQuotaErr(t *testing.T) {
+	quotaErr := &QuotaError{Provider: "genderize", ResetAt: time.Now().Add(time.Hour)}
+
+	enrichment := Enrichment{}
+	enrichment.SetError(FieldAge, ErrNoPrediction)
+	if got := enrichment.QuotaErr(); got != nil {
+		t.Fatalf("QuotaErr() = %v, want nil", got)
+	}
+
+	enrichment.SetError(FieldGender, errors.Join(fmt.Errorf("api: %w", quotaErr), fmt.Errorf("local: %w", ErrNoPrediction)))
+	if got := enrichment.QuotaErr(); got != quotaErr {
+		t.Errorf("QuotaErr() = %v, want %v", got, quotaErr)
+	}
+}
@@ -0,0 +1,284 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+type FilterField string
+
+const (
+	FilterID                  FilterField = "id"
+	FilterName                FilterField = "name"
+	FilterResolvedName        FilterField = "resolved_name"
+	FilterCanonicalName       FilterField = "canonical_name"
+	FilterSurname             FilterField = "surname"
+	FilterPatronymic          FilterField = "patronymic"
+	FilterAge                 FilterField = "age"
+	FilterAgeCount            FilterField = "age_count"
+	FilterAgeSource           FilterField = "age_source"
+	FilterAgeConfidence       FilterField = "age_confidence"
+	FilterGender              FilterField = "gender"
+	FilterGenderProbability   FilterField = "gender_probability"
+	FilterGenderCount         FilterField = "gender_count"
+	FilterGenderSource        FilterField = "gender_source"
+	FilterNationality         FilterField = "nationality"
+	FilterCountry             FilterField = "country"
+	FilterNationalitySource   FilterField = "nationality_source"
+	FilterCountryHint         FilterField = "country_hint"
+	FilterLocalizationCountry FilterField = "localization_country"
+	FilterEnrichmentStatus    FilterField = "enrichment_status"
+	FilterNeedsReview         FilterField = "needs_review"
+	FilterEnrichedAt          FilterField = "enriched_at"
+	FilterCreatedAt           FilterField = "created_at"
+	FilterUpdatedAt           FilterField = "updated_at"
+	FilterText                FilterField = "text"
+)
+
+type FilterOp string
+
+const (
+	OpEq       FilterOp = "eq"
+	OpNe       FilterOp = "ne"
+	OpIn       FilterOp = "in"
+	OpGt       FilterOp = "gt"
+	OpGte      FilterOp = "gte"
+	OpLt       FilterOp = "lt"
+	OpLte      FilterOp = "lte"
+	OpRange    FilterOp = "range"
+	OpPrefix   FilterOp = "prefix"
+	OpContains FilterOp = "contains"
+	OpIsNull   FilterOp = "is_null"
+	OpNotNull  FilterOp = "not_null"
+)
+
+type FieldKind int
+
+const (
+	KindString FieldKind = iota
+	KindInt
+	KindFloat
+	KindBool
+	KindTime
+	KindCountry
+	KindText
+)
+
+type FieldSpec struct {
+	Kind     FieldKind
+	Nullable bool
+}
+
+var FilterFields = map[FilterField]FieldSpec{
+	FilterID:                  {Kind: KindInt},
+	FilterName:                {Kind: KindString},
+	FilterResolvedName:        {Kind: KindString},
+	FilterCanonicalName:       {Kind: KindString},
+	FilterSurname:             {Kind: KindString},
+	FilterPatronymic:          {Kind: KindString, Nullable: true},
+	FilterAge:                 {Kind: KindInt, Nullable: true},
+	FilterAgeCount:            {Kind: KindInt},
+	FilterAgeSource:           {Kind: KindString},
+	FilterAgeConfidence:       {Kind: KindFloat},
+	FilterGender:              {Kind: KindString, Nullable: true},
+	FilterGenderProbability:   {Kind: KindFloat},
+	FilterGenderCount:         {Kind: KindInt},
+	FilterGenderSource:        {Kind: KindString},
+	FilterNationality:         {Kind: KindString, Nullable: true},
+	FilterCountry:             {Kind: KindCountry},
+	FilterNationalitySource:   {Kind: KindString},
+	FilterCountryHint:         {Kind: KindString},
+	FilterLocalizationCountry: {Kind: KindString},
+	FilterEnrichmentStatus:    {Kind: KindString},
+	FilterNeedsReview:         {Kind: KindBool},
+	FilterEnrichedAt:          {Kind: KindTime, Nullable: true},
+	FilterCreatedAt:           {Kind: KindTime},
+	FilterUpdatedAt:           {Kind: KindTime},
+	FilterText:                {Kind: KindText},
+}
+
+var kindOps = map[FieldKind][]FilterOp{
+	KindString:  {OpEq, OpNe, OpIn, OpPrefix, OpContains},
+	KindInt:     {OpEq, OpNe, OpIn, OpGt, OpGte, OpLt, OpLte, OpRange},
+	KindFloat:   {OpEq, OpNe, OpIn, OpGt, OpGte, OpLt, OpLte, OpRange},
+	KindBool:    {OpEq, OpNe},
+	KindTime:    {OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpRange},
+	KindCountry: {OpEq, OpNe, OpIn},
+	KindText:    {OpContains},
+}
+
+func (s FieldSpec) Supports(op FilterOp) bool {
+	if op == OpIsNull || op == OpNotNull {
+		return s.Nullable
+	}
+
+	return slices.Contains(kindOps[s.Kind], op)
+}
+
+type Condition struct {
+	Field  FilterField
+	Op     FilterOp
+	Value  any
+	Values []any
+	From   any
+	To     any
+}
+
+type Filter struct {
+	Conditions []Condition
+}
+
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+func (f *Filter) Eq(field FilterField, value any) *Filter {
+	return f.add(Condition{Field: field, Op: OpEq, Value: value})
+}
+
+func (f *Filter) Ne(field FilterField, value any) *Filter {
+	return f.add(Condition{Field: field, Op: OpNe, Value: value})
+}
+
+func (f *Filter) In(field FilterField, values ...any) *Filter {
+	return f.add(Condition{Field: field, Op: OpIn, Values: values})
+}
+
+func (f *Filter) Range(field FilterField, from, to any) *Filter {
+	return f.add(Condition{Field: field, Op: OpRange, From: from, To: to})
+}
+
+func (f *Filter) Prefix(field FilterField, value string) *Filter {
+	return f.add(Condition{Field: field, Op: OpPrefix, Value: value})
+}
+
+func (f *Filter) Contains(field FilterField, value string) *Filter {
+	return f.add(Condition{Field: field, Op: OpContains, Value: value})
+}
+
+func (f *Filter) IsNull(field FilterField) *Filter {
+	return f.add(Condition{Field: field, Op: OpIsNull})
+}
+
+func (f *Filter) NotNull(field FilterField) *Filter {
+	return f.add(Condition{Field: field, Op: OpNotNull})
+}
+
+func (f *Filter) add(condition Condition) *Filter {
+	f.Conditions = append(f.Conditions, condition)
+	return f
+}
+
+func (f *Filter) Empty() bool {
+	return f == nil || len(f.Conditions) == 0
+}
+
+func (f *Filter) Validate() error {
+	if f == nil {
+		return nil
+	}
+
+	for _, condition := range f.Conditions {
+		if err := condition.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *Filter) String() string {
+	if f.Empty() {
+		return "{}"
+	}
+
+	parts := make([]string, len(f.Conditions))
+	for i, condition := range f.Conditions {
+		parts[i] = condition.String()
+	}
+
+	return strings.Join(parts, " AND ")
+}
+
+func (c Condition) Validate() error {
+	spec, ok := FilterFields[c.Field]
+	if !ok {
+		return fmt.Errorf("%w: unsupported field %q", ErrInvalidFilter, c.Field)
+	}
+
+	if !spec.Supports(c.Op) {
+		return fmt.Errorf("%w: operator %q is not supported for %s", ErrInvalidFilter, c.Op, c.Field)
+	}
+
+	switch c.Op {
+	case OpIsNull, OpNotNull:
+		return nil
+	case OpIn:
+		if len(c.Values) == 0 {
+			return fmt.Errorf("%w: %s in requires at least one value", ErrInvalidFilter, c.Field)
+		}
+		for _, value := range c.Values {
+			if err := checkKind(c.Field, spec.Kind, value); err != nil {
+				return err
+			}
+		}
+	case OpRange:
+		if c.From == nil && c.To == nil {
+			return fmt.Errorf("%w: %s range requires a lower or upper bound", ErrInvalidFilter, c.Field)
+		}
+		for _, bound := range []any{c.From, c.To} {
+			if bound == nil {
+				continue
+			}
+			if err := checkKind(c.Field, spec.Kind, bound); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := checkKind(c.Field, spec.Kind, c.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c Condition) String() string {
+	switch c.Op {
+	case OpIsNull, OpNotNull:
+		return fmt.Sprintf("%s %s", c.Field, c.Op)
+	case OpIn:
+		return fmt.Sprintf("%s in %v", c.Field, c.Values)
+	case OpRange:
+		return fmt.Sprintf("%s range [%v, %v]", c.Field, c.From, c.To)
+	default:
+		return fmt.Sprintf("%s %s %v", c.Field, c.Op, c.Value)
+	}
+}
+
+func checkKind(field FilterField, kind FieldKind, value any) error {
+	var ok bool
+	switch kind {
+	case KindString, KindCountry, KindText:
+		_, ok = value.(string)
+	case KindInt:
+		_, ok = value.(int)
+	case KindFloat:
+		_, ok = value.(float64)
+	case KindBool:
+		_, ok = value.(bool)
+	case KindTime:
+		_, ok = value.(time.Time)
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: unexpected value %v for %s", ErrInvalidFilter, value, field)
+	}
+
+	return nil
+}
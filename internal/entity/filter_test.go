@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFilterValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  *Filter
+		wantErr bool
+	}{
+		{name: "nil filter", filter: nil},
+		{name: "empty filter", filter: NewFilter()},
+		{name: "string equality", filter: NewFilter().Eq(FilterGender, "female")},
+		{name: "int range", filter: NewFilter().Range(FilterAge, 20, 40)},
+		{name: "open range", filter: NewFilter().Range(FilterAge, nil, 40)},
+		{name: "time range", filter: NewFilter().Range(FilterCreatedAt, time.Now(), nil)},
+		{name: "country in", filter: NewFilter().In(FilterCountry, "RU", "UA")},
+		{name: "text contains", filter: NewFilter().Contains(FilterText, "иван")},
+		{name: "nullable age", filter: NewFilter().IsNull(FilterAge)},
+		{name: "nullable patronymic", filter: NewFilter().IsNull(FilterPatronymic)},
+		{name: "present patronymic", filter: NewFilter().NotNull(FilterPatronymic)},
+		{name: "unknown field", filter: NewFilter().Eq(FilterField("password"), "x"), wantErr: true},
+		{name: "name is never null", filter: NewFilter().IsNull(FilterName), wantErr: true},
+		{name: "prefix on int", filter: NewFilter().Prefix(FilterAge, "4"), wantErr: true},
+		{name: "string for int", filter: NewFilter().Eq(FilterAge, "forty"), wantErr: true},
+		{name: "int for float", filter: NewFilter().Eq(FilterGenderProbability, 1), wantErr: true},
+		{name: "empty in", filter: NewFilter().In(FilterNationality), wantErr: true},
+		{name: "mixed in", filter: NewFilter().In(FilterAge, 20, "30"), wantErr: true},
+		{name: "range without bounds", filter: NewFilter().Range(FilterAge, nil, nil), wantErr: true},
+		{name: "range on bool", filter: NewFilter().Range(FilterNeedsReview, true, false), wantErr: true},
+		{name: "text equality", filter: NewFilter().Eq(FilterText, "иван"), wantErr: true},
+		{name: "country prefix", filter: NewFilter().Prefix(FilterCountry, "R"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidFilter) {
+				t.Errorf("Validate() error = %v, want ErrInvalidFilter", err)
+			}
+		})
+	}
+}
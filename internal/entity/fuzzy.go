@@ -0,0 +1,28 @@
+package entity
+
+const (
+	DefaultFuzzyMinScore = 0.3
+	MaxFuzzyTerms        = 5
+)
+
+type PhoneticKeys struct {
+	Name       string
+	Surname    string
+	Patronymic string
+}
+
+type FuzzyTerm struct {
+	Text     string
+	Phonetic string
+}
+
+type FuzzyQuery struct {
+	Text     string
+	Terms    []FuzzyTerm
+	MinScore float64
+}
+
+type ScoredPerson struct {
+	Person *Person
+	Score  float64
+}
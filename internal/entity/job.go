@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+type EnrichmentStatus string
+
+const (
+	EnrichmentPending  EnrichmentStatus = "pending"
+	EnrichmentEnriched EnrichmentStatus = "enriched"
+	EnrichmentPartial  EnrichmentStatus = "partial"
+	EnrichmentFailed   EnrichmentStatus = "failed"
+)
+
+func (s EnrichmentStatus) Valid() bool {
+	switch s {
+	case EnrichmentPending, EnrichmentEnriched, EnrichmentPartial, EnrichmentFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+type JobStatus string
+
+const (
+	JobPending JobStatus = "pending"
+	JobRunning JobStatus = "running"
+	JobDone    JobStatus = "done"
+	JobDead    JobStatus = "dead"
+)
+
+type EnrichmentJob struct {
+	ID        int64
+	PersonID  int64
+	Status    JobStatus
+	Attempts  int
+	LastError string
+	RunAt     time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
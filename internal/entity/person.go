@@ -1,13 +1,145 @@
 package entity
 
+import "time"
+
 type Person struct {
-	ID          int64  `json:"id"`
-	Name        string `json:"name"`
-	Surname     string `json:"surname"`
-	Patronymic  string `json:"patronymic,omitempty"`
-	Age         int    `json:"age"`
-	Gender      string `json:"gender"`
-	Nationality string `json:"nationality"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID                  int64                `json:"id"`
+	Name                string               `json:"name"`
+	ResolvedName        string               `json:"resolved_name,omitempty"`
+	CanonicalName       string               `json:"canonical_name"`
+	Surname             string               `json:"surname"`
+	Patronymic          string               `json:"patronymic,omitempty"`
+	Phonetic            PhoneticKeys         `json:"-"`
+	CanonicalVersion    int                  `json:"-"`
+	Age                 *int                 `json:"age"`
+	AgeCount            int                  `json:"age_count"`
+	AgeSource           string               `json:"age_source,omitempty"`
+	AgeConfidence       float64              `json:"age_confidence,omitempty"`
+	Gender              *string              `json:"gender"`
+	GenderProbability   float64              `json:"gender_probability"`
+	GenderCount         int                  `json:"gender_count"`
+	GenderSource        string               `json:"gender_source,omitempty"`
+	Nationality         *string              `json:"nationality"`
+	Countries           []CountryProbability `json:"countries"`
+	NameCountries       []CountryProbability `json:"name_countries,omitempty"`
+	SurnameCountries    []CountryProbability `json:"surname_countries,omitempty"`
+	NationalitySource   string               `json:"nationality_source,omitempty"`
+	CountryHint         string               `json:"country_hint,omitempty"`
+	LocalizationCountry string               `json:"localization_country,omitempty"`
+	EnrichmentStatus    EnrichmentStatus     `json:"enrichment_status"`
+	NeedsReview         bool                 `json:"needs_review"`
+	Review              *PendingReview       `json:"review,omitempty"`
+	EnrichedAt          *string              `json:"enriched_at,omitempty"`
+	CreatedAt           string               `json:"created_at"`
+	UpdatedAt           string               `json:"updated_at"`
+}
+
+func (p *Person) ApplyEnrichment(e Enrichment) bool {
+	if e.Failed() {
+		p.updateStatus()
+		return false
+	}
+
+	if e.Age != nil && p.AgeSource != SourceManual {
+		p.applyAge(e.Age)
+	}
+	if e.Gender != nil && p.GenderSource != SourceManual {
+		p.applyGender(e.Gender)
+	}
+	if e.Nationality != nil && p.NationalitySource != SourceManual {
+		p.applyNationality(e.Nationality)
+	}
+
+	p.Review = nil
+	if e.Review != nil {
+		review := *e.Review
+		p.Review = &review
+		p.clearFieldsUnderReview(e)
+		p.ClearReviewedFields()
+	}
+	p.NeedsReview = p.Review != nil
+
+	enrichedAt := time.Now().Format(time.RFC3339)
+	p.EnrichedAt = &enrichedAt
+
+	p.updateStatus()
+	return true
+}
+
+func (p *Person) applyAge(prediction *AgePrediction) {
+	age := prediction.Age
+	p.Age = &age
+	p.AgeCount = prediction.Count
+	p.AgeSource = sourceOrProvider(prediction.Source)
+	p.AgeConfidence = prediction.Confidence
+}
+
+func (p *Person) applyGender(prediction *GenderPrediction) {
+	gender := prediction.Gender
+	p.Gender = &gender
+	p.GenderProbability = prediction.Probability
+	p.GenderCount = prediction.Count
+	p.GenderSource = sourceOrProvider(prediction.Source)
+}
+
+func (p *Person) applyNationality(prediction *NationalityPrediction) {
+	nationality := prediction.Top()
+	p.Nationality = &nationality
+	p.Countries = prediction.Countries
+	p.NameCountries = prediction.NameCountries
+	p.SurnameCountries = prediction.SurnameCountries
+	p.NationalitySource = sourceOrProvider(prediction.Source)
+}
+
+func (p *Person) clearFieldsUnderReview(e Enrichment) {
+	if p.Review.Age != nil && e.Age == nil && p.AgeSource != SourceManual {
+		p.Age = nil
+		p.AgeCount = 0
+		p.AgeSource = ""
+		p.AgeConfidence = 0
+	}
+	if p.Review.Gender != nil && e.Gender == nil && p.GenderSource != SourceManual {
+		p.Gender = nil
+		p.GenderProbability = 0
+		p.GenderCount = 0
+		p.GenderSource = ""
+	}
+	if p.Review.Nationality != nil && e.Nationality == nil && p.NationalitySource != SourceManual {
+		p.Nationality = nil
+		p.Countries = nil
+		p.NameCountries = nil
+		p.SurnameCountries = nil
+		p.NationalitySource = ""
+	}
+}
+
+func (p *Person) updateStatus() {
+	if len(p.MissingFields()) == 0 {
+		p.EnrichmentStatus = EnrichmentEnriched
+	} else {
+		p.EnrichmentStatus = EnrichmentPartial
+	}
+}
+
+func (p *Person) MissingFields() []string {
+	var missing []string
+
+	if p.Age == nil {
+		missing = append(missing, FieldAge)
+	}
+	if p.Gender == nil {
+		missing = append(missing, FieldGender)
+	}
+	if p.Nationality == nil {
+		missing = append(missing, FieldNationality)
+	}
+
+	return missing
+}
+
+func sourceOrProvider(source string) string {
+	if source == "" {
+		return SourceProvider
+	}
+	return source
 }
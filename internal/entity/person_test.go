@@ -0,0 +1,135 @@
+package entity
+
+import "testing"
+
+func TestApplyEnrichmentKeepsManualFields(t *testing.T) {
+	manualAge, manualGender, manualNationality := 33, "female", "KZ"
+
+	enrichment := Enrichment{
+		Age:         &AgePrediction{Age: 51, Count: 100},
+		Gender:      &GenderPrediction{Gender: "male", Probability: 0.99, Count: 100},
+		Nationality: &NationalityPrediction{Countries: []CountryProbability{{CountryID: "RU", Probability: 0.9}}, Count: 100},
+		Review: &PendingReview{
+			Age:    &AgePrediction{Age: 60, Count: 1},
+			Gender: &GenderPrediction{Gender: "male", Probability: 0.51, Count: 1},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		person          Person
+		wantAge         int
+		wantGender      string
+		wantNationality string
+		wantReview      bool
+	}{
+		{
+			name:            "provider values are replaced",
+			person:          Person{},
+			wantAge:         51,
+			wantGender:      "male",
+			wantNationality: "RU",
+			wantReview:      true,
+		},
+		{
+			name: "manual values survive re-enrichment",
+			person: Person{
+				Age: &manualAge, AgeSource: SourceManual,
+				Gender: &manualGender, GenderSource: SourceManual,
+				Nationality: &manualNationality, NationalitySource: SourceManual,
+			},
+			wantAge:         manualAge,
+			wantGender:      manualGender,
+			wantNationality: manualNationality,
+			wantReview:      false,
+		},
+		{
+			name: "only manual fields are kept",
+			person: Person{
+				Gender: &manualGender, GenderSource: SourceManual,
+			},
+			wantAge:         51,
+			wantGender:      manualGender,
+			wantNationality: "RU",
+			wantReview:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			person := tt.person
+			person.ApplyEnrichment(enrichment)
+
+			if person.Age == nil || *person.Age != tt.wantAge {
+				t.Errorf("age = %v, want %d", person.Age, tt.wantAge)
+			}
+			if person.Gender == nil || *person.Gender != tt.wantGender {
+				t.Errorf("gender = %v, want %s", person.Gender, tt.wantGender)
+			}
+			if person.Nationality == nil || *person.Nationality != tt.wantNationality {
+				t.Errorf("nationality = %v, want %s", person.Nationality, tt.wantNationality)
+			}
+			if person.NeedsReview != tt.wantReview {
+				t.Errorf("needs review = %v, want %v", person.NeedsReview, tt.wantReview)
+			}
+			if person.GenderSource == SourceManual && person.Review != nil && person.Review.Gender != nil {
+				t.Error("review proposes a value for a manual gender")
+			}
+		})
+	}
+
+	if enrichment.Review.Gender == nil {
+		t.Error("ApplyEnrichment modified the enrichment review")
+	}
+}
+
+func TestApplyEnrichmentSkipsFailedEnrichment(t *testing.T) {
+	age, enrichedAt := 40, "2024-01-01T00:00:00Z"
+	person := Person{Age: &age, AgeSource: SourceProvider, EnrichedAt: &enrichedAt}
+
+	enrichment := Enrichment{}
+	enrichment.SetError(FieldAge, ErrProviderUnavailable)
+	enrichment.SetError(FieldGender, ErrProviderUnavailable)
+	enrichment.SetError(FieldNationality, ErrProviderUnavailable)
+
+	if person.ApplyEnrichment(enrichment) {
+		t.Error("ApplyEnrichment() = true, want false when every field failed")
+	}
+	if person.EnrichedAt != &enrichedAt {
+		t.Errorf("enriched at = %v, want unchanged", *person.EnrichedAt)
+	}
+
+	unknown := Enrichment{}
+	unknown.SetError(FieldAge, ErrNoPrediction)
+	if !person.ApplyEnrichment(unknown) {
+		t.Error("ApplyEnrichment() = false, want true when the provider has no prediction")
+	}
+}
+
+func TestApplyEnrichmentClearsFieldsUnderReview(t *testing.T) {
+	age, gender, nationality := 40, "male", "RU"
+	person := Person{
+		Age: &age, AgeCount: 100, AgeSource: SourceProvider,
+		Gender: &gender, GenderProbability: 0.99, GenderCount: 100, GenderSource: SourceProvider,
+		Nationality: &nationality, Countries: []CountryProbability{{CountryID: "RU", Probability: 0.9}}, NationalitySource: SourceProvider,
+	}
+
+	person.ApplyEnrichment(Enrichment{
+		Age:         &AgePrediction{Age: 41, Count: 100},
+		Nationality: &NationalityPrediction{Countries: []CountryProbability{{CountryID: "RU", Probability: 0.9}}, Count: 100},
+		Review: &PendingReview{
+			Gender: &GenderPrediction{Gender: "female", Probability: 0.51, Count: 2, Source: SourceProvider},
+		},
+	})
+
+	if person.Gender != nil || person.GenderProbability != 0 || person.GenderCount != 0 || person.GenderSource != "" {
+		t.Errorf("gender under review kept the stale value %v (probability %v, count %d, source %q)",
+			person.Gender, person.GenderProbability, person.GenderCount, person.GenderSource)
+	}
+	if person.Age == nil || *person.Age != 41 {
+		t.Errorf("age = %v, want 41", person.Age)
+	}
+	if !person.NeedsReview || person.EnrichmentStatus != EnrichmentPartial {
+		t.Errorf("needs review = %v, status = %s, want true and %s", person.NeedsReview, person.EnrichmentStatus, EnrichmentPartial)
+	}
+}
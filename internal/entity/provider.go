@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrProviderUnavailable = errors.New("enrichment provider unavailable")
+
+type ProviderStatus struct {
+	Provider string     `json:"provider"`
+	State    string     `json:"state"`
+	Failures int        `json:"failures"`
+	OpenedAt *time.Time `json:"opened_at,omitempty"`
+}
+
+type ProviderHealth interface {
+	ProviderStatuses() []ProviderStatus
+	QuotaUsage(ctx context.Context) ([]QuotaUsage, error)
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrQuotaExhausted = errors.New("provider daily quota exhausted")
+
+type QuotaError struct {
+	Provider string
+	ResetAt  time.Time
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s daily quota exhausted until %s", e.Provider, e.ResetAt.Format(time.RFC3339))
+}
+
+func (e *QuotaError) Unwrap() error {
+	return ErrQuotaExhausted
+}
+
+type QuotaUsage struct {
+	Provider  string     `json:"provider"`
+	Day       string     `json:"day"`
+	Used      int        `json:"used"`
+	Limit     int        `json:"limit"`
+	Remaining *int       `json:"remaining,omitempty"`
+	ResetAt   *time.Time `json:"reset_at,omitempty"`
+}
+
+type QuotaRepository interface {
+	Reserve(ctx context.Context, provider, day string, units, limit int) (bool, *QuotaUsage, error)
+	Observe(ctx context.Context, provider, day string, remaining int, resetAt time.Time) error
+	Usage(ctx context.Context, day string) ([]*QuotaUsage, error)
+}
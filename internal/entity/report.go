@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+type EnrichmentReport struct {
+	PersonID      int64         `json:"person_id"`
+	Changes       []FieldChange `json:"changes"`
+	MissingFields []string      `json:"missing_fields,omitempty"`
+	Error         string        `json:"error,omitempty"`
+}
+
+type RefreshReport struct {
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt time.Time          `json:"finished_at"`
+	Processed  int                `json:"processed"`
+	Changed    int                `json:"changed"`
+	Failed     int                `json:"failed"`
+	Persons    []EnrichmentReport `json:"persons"`
+}
+
+func DiffPersons(before, after *Person) []FieldChange {
+	var changes []FieldChange
+
+	if !equalPtr(before.Age, after.Age) {
+		changes = append(changes, FieldChange{Field: FieldAge, Old: before.Age, New: after.Age})
+	}
+	if !equalPtr(before.Gender, after.Gender) {
+		changes = append(changes, FieldChange{Field: FieldGender, Old: before.Gender, New: after.Gender})
+	}
+	if before.GenderProbability != after.GenderProbability {
+		changes = append(changes, FieldChange{Field: "gender_probability", Old: before.GenderProbability, New: after.GenderProbability})
+	}
+	if !equalPtr(before.Nationality, after.Nationality) {
+		changes = append(changes, FieldChange{Field: FieldNationality, Old: before.Nationality, New: after.Nationality})
+	}
+
+	return changes
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -1,9 +1,29 @@
 package entity
 
+import (
+	"context"
+	"time"
+)
+
 type PersonRepository interface {
-	Create(person *Person) (int64, error)
-	GetByID(id int64) (*Person, error)
-	GetAll(filter map[string]interface{}, page, limit int) ([]*Person, int, error)
-	Update(person *Person) error
-	Delete(id int64) error
+	Create(ctx context.Context, person *Person) (int64, error)
+	GetByID(ctx context.Context, id int64) (*Person, error)
+	GetAll(ctx context.Context, filter *Filter, page, limit int) ([]*Person, int, error)
+	Search(ctx context.Context, query *SearchQuery) ([]*Person, int, error)
+	FuzzySearch(ctx context.Context, query *FuzzyQuery, filter *Filter, page, limit int) ([]*ScoredPerson, int, error)
+	GetUncanonicalized(ctx context.Context, version, limit int) ([]*Person, error)
+	UpdateCanonical(ctx context.Context, person *Person) error
+	Suggest(ctx context.Context, field FilterField, prefix string, limit int) ([]*Suggestion, error)
+	Update(ctx context.Context, person *Person) error
+	Delete(ctx context.Context, id int64) error
+	GetStale(ctx context.Context, enrichedBefore time.Time, limit int) ([]*Person, error)
+}
+
+type EnrichmentJobRepository interface {
+	Enqueue(ctx context.Context, personID int64) (*EnrichmentJob, error)
+	Claim(ctx context.Context, lease time.Duration, maxAttempts int) (*EnrichmentJob, error)
+	Complete(ctx context.Context, id int64) error
+	Retry(ctx context.Context, id int64, lastError string, runAt time.Time) error
+	Bury(ctx context.Context, id int64, lastError string) error
+	GetLatestByPersonID(ctx context.Context, personID int64) (*EnrichmentJob, error)
 }
@@ -0,0 +1,189 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	ErrNoPendingReview    = errors.New("no pending review for field")
+	ErrInvalidReviewValue = errors.New("invalid review value")
+)
+
+const (
+	ReviewAccept   = "accept"
+	ReviewOverride = "override"
+)
+
+type Threshold struct {
+	MinProbability float64
+	MinCount       int
+}
+
+func (t Threshold) passes(probability float64, count int) bool {
+	return probability >= t.MinProbability && count >= t.MinCount
+}
+
+type Thresholds struct {
+	Age         Threshold
+	Gender      Threshold
+	Nationality Threshold
+}
+
+type PendingReview struct {
+	Age         *AgePrediction         `json:"age,omitempty"`
+	Gender      *GenderPrediction      `json:"gender,omitempty"`
+	Nationality *NationalityPrediction `json:"nationality,omitempty"`
+}
+
+func (r *PendingReview) empty() bool {
+	return r.Age == nil && r.Gender == nil && r.Nationality == nil
+}
+
+func (t Thresholds) Apply(e *Enrichment) {
+	var review PendingReview
+
+	if e.Nationality != nil && len(e.Nationality.Countries) == 0 {
+		e.Nationality = nil
+		if _, ok := e.Errors[FieldNationality]; !ok {
+			e.SetError(FieldNationality, ErrNoPrediction)
+		}
+	}
+	if e.Age != nil && !t.Age.passes(1, e.Age.Count) {
+		proposal := *e.Age
+		proposal.Source = sourceOrProvider(proposal.Source)
+		review.Age, e.Age = &proposal, nil
+	}
+	if e.Gender != nil && e.Gender.Source != SourceRules && !t.Gender.passes(e.Gender.Probability, e.Gender.Count) {
+		proposal := *e.Gender
+		proposal.Source = sourceOrProvider(proposal.Source)
+		review.Gender, e.Gender = &proposal, nil
+	}
+	if e.Nationality != nil && !t.Nationality.passes(e.Nationality.Countries[0].Probability, e.Nationality.Count) {
+		proposal := *e.Nationality
+		proposal.Source = sourceOrProvider(proposal.Source)
+		review.Nationality, e.Nationality = &proposal, nil
+	}
+
+	if !review.empty() {
+		e.Review = &review
+	}
+}
+
+func (p *Person) AcceptReview(field string) error {
+	if p.Review == nil {
+		return fmt.Errorf("%w: %s", ErrNoPendingReview, field)
+	}
+
+	switch field {
+	case FieldAge:
+		if p.Review.Age == nil {
+			return fmt.Errorf("%w: %s", ErrNoPendingReview, field)
+		}
+		p.applyAge(p.Review.Age)
+		p.Review.Age = nil
+	case FieldGender:
+		if p.Review.Gender == nil {
+			return fmt.Errorf("%w: %s", ErrNoPendingReview, field)
+		}
+		p.applyGender(p.Review.Gender)
+		p.Review.Gender = nil
+	case FieldNationality:
+		if p.Review.Nationality == nil {
+			return fmt.Errorf("%w: %s", ErrNoPendingReview, field)
+		}
+		p.applyNationality(p.Review.Nationality)
+		p.Review.Nationality = nil
+	default:
+		return fmt.Errorf("%w: unknown field %q", ErrInvalidReviewValue, field)
+	}
+
+	p.finishReview()
+	return nil
+}
+
+func (p *Person) OverrideReview(field, value string) error {
+	if value == "" {
+		return fmt.Errorf("%w: value is required", ErrInvalidReviewValue)
+	}
+	if p.Review == nil {
+		return fmt.Errorf("%w: %s", ErrNoPendingReview, field)
+	}
+
+	switch field {
+	case FieldAge:
+		if p.Review.Age == nil {
+			return fmt.Errorf("%w: %s", ErrNoPendingReview, field)
+		}
+		age, err := strconv.Atoi(value)
+		if err != nil || age < 0 {
+			return fmt.Errorf("%w: age must be a non-negative integer", ErrInvalidReviewValue)
+		}
+		p.Age = &age
+		p.AgeCount = 0
+		p.AgeSource = SourceManual
+		p.AgeConfidence = 0
+		p.Review.Age = nil
+	case FieldGender:
+		if p.Review.Gender == nil {
+			return fmt.Errorf("%w: %s", ErrNoPendingReview, field)
+		}
+		if value != "male" && value != "female" {
+			return fmt.Errorf("%w: gender must be male or female", ErrInvalidReviewValue)
+		}
+		p.Gender = &value
+		p.GenderProbability = 0
+		p.GenderCount = 0
+		p.GenderSource = SourceManual
+		p.Review.Gender = nil
+	case FieldNationality:
+		if p.Review.Nationality == nil {
+			return fmt.Errorf("%w: %s", ErrNoPendingReview, field)
+		}
+		if !isCountryCode(value) {
+			return fmt.Errorf("%w: nationality must be an uppercase ISO 3166-1 alpha-2 code", ErrInvalidReviewValue)
+		}
+		p.Nationality = &value
+		p.Countries = nil
+		p.NameCountries = nil
+		p.SurnameCountries = nil
+		p.NationalitySource = SourceManual
+		p.Review.Nationality = nil
+	default:
+		return fmt.Errorf("%w: unknown field %q", ErrInvalidReviewValue, field)
+	}
+
+	p.finishReview()
+	return nil
+}
+
+func isCountryCode(value string) bool {
+	return len(value) == 2 && value[0] >= 'A' && value[0] <= 'Z' && value[1] >= 'A' && value[1] <= 'Z'
+}
+
+func (p *Person) ClearReviewedFields() {
+	if p.Review == nil {
+		return
+	}
+
+	if p.AgeSource == SourceManual {
+		p.Review.Age = nil
+	}
+	if p.GenderSource == SourceManual {
+		p.Review.Gender = nil
+	}
+	if p.NationalitySource == SourceManual {
+		p.Review.Nationality = nil
+	}
+
+	p.finishReview()
+}
+
+func (p *Person) finishReview() {
+	if p.Review != nil && p.Review.empty() {
+		p.Review = nil
+	}
+	p.NeedsReview = p.Review != nil
+	p.updateStatus()
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOverrideReview(t *testing.T) {
+	tests := []struct {
+		field   string
+		value   string
+		wantErr error
+	}{
+		{field: FieldAge, value: "42"},
+		{field: FieldAge, value: "-1", wantErr: ErrInvalidReviewValue},
+		{field: FieldAge, value: "forty", wantErr: ErrInvalidReviewValue},
+		{field: FieldGender, value: "female"},
+		{field: FieldGender, value: "male"},
+		{field: FieldGender, value: "banana", wantErr: ErrInvalidReviewValue},
+		{field: FieldGender, value: "Female", wantErr: ErrInvalidReviewValue},
+		{field: FieldNationality, value: "RU"},
+		{field: FieldNationality, value: "ru", wantErr: ErrInvalidReviewValue},
+		{field: FieldNationality, value: "RUS", wantErr: ErrInvalidReviewValue},
+		{field: FieldNationality, value: "R1", wantErr: ErrInvalidReviewValue},
+		{field: FieldNationality, value: "", wantErr: ErrInvalidReviewValue},
+		{field: "height", value: "180", wantErr: ErrInvalidReviewValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field+"="+tt.value, func(t *testing.T) {
+			person := &Person{
+				Review: &PendingReview{
+					Age:         &AgePrediction{Age: 30, Count: 1},
+					Gender:      &GenderPrediction{Gender: "male", Probability: 0.5, Count: 1},
+					Nationality: &NationalityPrediction{Countries: []CountryProbability{{CountryID: "UA", Probability: 0.2}}, Count: 1},
+				},
+				NeedsReview:       true,
+				GenderProbability: 0.5,
+				GenderCount:       1,
+			}
+
+			err := person.OverrideReview(tt.field, tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OverrideReview(%q, %q) error = %v, want %v", tt.field, tt.value, err, tt.wantErr)
+			}
+			if err != nil {
+				if person.AgeSource == SourceManual || person.GenderSource == SourceManual || person.NationalitySource == SourceManual {
+					t.Error("rejected override was stored as manual")
+				}
+				return
+			}
+
+			switch tt.field {
+			case FieldAge:
+				if person.AgeSource != SourceManual || person.Review.Age != nil {
+					t.Error("age override was not applied")
+				}
+			case FieldGender:
+				if person.GenderSource != SourceManual || *person.Gender != tt.value {
+					t.Error("gender override was not applied")
+				}
+				if person.GenderProbability != 0 || person.GenderCount != 0 {
+					t.Errorf("manual gender kept provider probability %v and count %d", person.GenderProbability, person.GenderCount)
+				}
+			case FieldNationality:
+				if person.NationalitySource != SourceManual || *person.Nationality != tt.value {
+					t.Error("nationality override was not applied")
+				}
+			}
+		})
+	}
+}
+
+func TestThresholdsApplyWithoutCountries(t *testing.T) {
+	thresholds := Thresholds{Nationality: Threshold{MinProbability: 0.5}}
+	enrichment := &Enrichment{Nationality: &NationalityPrediction{Count: 10}}
+
+	thresholds.Apply(enrichment)
+
+	if enrichment.Nationality != nil {
+		t.Errorf("nationality = %+v, want nil", enrichment.Nationality)
+	}
+	if enrichment.Review != nil {
+		t.Errorf("review = %+v, want nil", enrichment.Review)
+	}
+	if !errors.Is(enrichment.Errors[FieldNationality], ErrNoPrediction) {
+		t.Errorf("nationality error = %v, want ErrNoPrediction", enrichment.Errors[FieldNationality])
+	}
+	if top := (NationalityPrediction{}).Top(); top != "" {
+		t.Errorf("Top() = %q, want empty", top)
+	}
+}
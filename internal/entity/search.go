@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	MaxSearchDepth      = 6
+	MaxSearchComplexity = 100
+	MaxSearchSort       = 3
+)
+
+type FilterNode struct {
+	And       []*FilterNode
+	Or        []*FilterNode
+	Not       *FilterNode
+	Condition *Condition
+}
+
+type SortOrder struct {
+	Field FilterField
+	Desc  bool
+}
+
+type SearchQuery struct {
+	Where *FilterNode
+	Sort  []SortOrder
+	Page  int
+	Limit int
+}
+
+func (f *Filter) Node() *FilterNode {
+	if f.Empty() {
+		return nil
+	}
+
+	node := &FilterNode{And: make([]*FilterNode, len(f.Conditions))}
+	for i := range f.Conditions {
+		node.And[i] = &FilterNode{Condition: &f.Conditions[i]}
+	}
+
+	return node
+}
+
+func (n *FilterNode) String() string {
+	switch {
+	case n == nil:
+		return "{}"
+	case n.Condition != nil:
+		return n.Condition.String()
+	case n.Not != nil:
+		return "NOT (" + n.Not.String() + ")"
+	}
+
+	operator, children := " AND ", n.And
+	if n.Or != nil {
+		operator, children = " OR ", n.Or
+	}
+
+	parts := make([]string, len(children))
+	for i, child := range children {
+		parts[i] = child.String()
+	}
+
+	return "(" + strings.Join(parts, operator) + ")"
+}
+
+func (q *SearchQuery) Validate() error {
+	if q.Where != nil {
+		complexity := 0
+		if err := q.Where.validate(1, &complexity); err != nil {
+			return err
+		}
+	}
+
+	if len(q.Sort) > MaxSearchSort {
+		return fmt.Errorf("%w: at most %d sort fields are allowed", ErrInvalidFilter, MaxSearchSort)
+	}
+	for _, order := range q.Sort {
+		spec, ok := FilterFields[order.Field]
+		if !ok || spec.Kind == KindCountry || spec.Kind == KindText {
+			return fmt.Errorf("%w: cannot sort by %q", ErrInvalidFilter, order.Field)
+		}
+	}
+
+	return nil
+}
+
+func (n *FilterNode) validate(depth int, complexity *int) error {
+	if depth > MaxSearchDepth {
+		return fmt.Errorf("%w: query is nested deeper than %d levels", ErrInvalidFilter, MaxSearchDepth)
+	}
+
+	*complexity++
+	if n.Condition != nil {
+		*complexity += len(n.Condition.Values)
+	}
+	if *complexity > MaxSearchComplexity {
+		return fmt.Errorf("%w: query has more than %d nodes and values", ErrInvalidFilter, MaxSearchComplexity)
+	}
+
+	set := 0
+	for _, present := range []bool{n.And != nil, n.Or != nil, n.Not != nil, n.Condition != nil} {
+		if present {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("%w: each node must be exactly one of and, or, not or a condition", ErrInvalidFilter)
+	}
+
+	switch {
+	case n.Condition != nil:
+		return n.Condition.Validate()
+	case n.Not != nil:
+		return n.Not.validate(depth+1, complexity)
+	}
+
+	children := n.And
+	if n.Or != nil {
+		children = n.Or
+	}
+	if len(children) == 0 {
+		return fmt.Errorf("%w: and/or nodes need at least one operand", ErrInvalidFilter)
+	}
+	for _, child := range children {
+		if child == nil {
+			return fmt.Errorf("%w: empty operand", ErrInvalidFilter)
+		}
+		if err := child.validate(depth+1, complexity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func cond(field FilterField, op FilterOp, value any) *FilterNode {
+	return &FilterNode{Condition: &Condition{Field: field, Op: op, Value: value}}
+}
+
+func nested(depth int) *FilterNode {
+	node := cond(FilterAge, OpGt, 40)
+	for i := 1; i < depth; i++ {
+		node = &FilterNode{Not: node}
+	}
+	return node
+}
+
+func TestSearchQueryValidate(t *testing.T) {
+	wide := &FilterNode{}
+	for i := 0; i < MaxSearchComplexity; i++ {
+		wide.Or = append(wide.Or, cond(FilterAge, OpEq, i))
+	}
+
+	tests := []struct {
+		name    string
+		query   SearchQuery
+		wantErr bool
+	}{
+		{name: "empty query", query: SearchQuery{}},
+		{
+			name: "and of or",
+			query: SearchQuery{Where: &FilterNode{And: []*FilterNode{
+				{Or: []*FilterNode{cond(FilterNationality, OpEq, "RU"), cond(FilterNationality, OpEq, "BY")}},
+				cond(FilterAge, OpGt, 40),
+			}}},
+		},
+		{name: "maximum depth", query: SearchQuery{Where: nested(MaxSearchDepth)}},
+		{name: "too deep", query: SearchQuery{Where: nested(MaxSearchDepth + 1)}, wantErr: true},
+		{name: "too complex", query: SearchQuery{Where: wide}, wantErr: true},
+		{name: "empty and", query: SearchQuery{Where: &FilterNode{And: []*FilterNode{}}}, wantErr: true},
+		{name: "nil operand", query: SearchQuery{Where: &FilterNode{Or: []*FilterNode{nil}}}, wantErr: true},
+		{
+			name: "mixed node",
+			query: SearchQuery{Where: &FilterNode{
+				Not:       cond(FilterAge, OpGt, 40),
+				Condition: &Condition{Field: FilterGender, Op: OpEq, Value: "male"},
+			}},
+			wantErr: true,
+		},
+		{name: "empty node", query: SearchQuery{Where: &FilterNode{}}, wantErr: true},
+		{name: "invalid condition", query: SearchQuery{Where: cond(FilterAge, OpPrefix, "4")}, wantErr: true},
+		{name: "sort", query: SearchQuery{Sort: []SortOrder{{Field: FilterAge, Desc: true}, {Field: FilterSurname}}}},
+		{name: "sort by text", query: SearchQuery{Sort: []SortOrder{{Field: FilterText}}}, wantErr: true},
+		{name: "sort by unknown", query: SearchQuery{Sort: []SortOrder{{Field: "password"}}}, wantErr: true},
+		{
+			name: "too many sort fields",
+			query: SearchQuery{Sort: []SortOrder{
+				{Field: FilterAge}, {Field: FilterName}, {Field: FilterSurname}, {Field: FilterID},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidFilter) {
+				t.Errorf("Validate() error = %v, want ErrInvalidFilter", err)
+			}
+		})
+	}
+}
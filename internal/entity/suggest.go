@@ -0,0 +1,19 @@
+package entity
+
+const (
+	DefaultSuggestLimit = 10
+	MaxSuggestLimit     = 50
+	MinSuggestPrefix    = 2
+	MaxSuggestPrefix    = 100
+)
+
+var SuggestFields = map[FilterField]bool{
+	FilterName:       true,
+	FilterSurname:    true,
+	FilterPatronymic: true,
+}
+
+type Suggestion struct {
+	Value string
+	Count int
+}
@@ -1,16 +1,52 @@
 package entity
 
+import (
+	"context"
+	"time"
+)
+
 type PersonUseCase interface {
-	Create(name, surname, patronymic string) (*Person, error)
-	GetByID(id int64) (*Person, error)
-	GetAll(filter map[string]interface{}, page, limit int) ([]*Person, int, error)
-	Update(person *Person) error
-	Delete(id int64) error
+	Create(ctx context.Context, name, surname, patronymic, countryID string) (*Person, error)
+	CreateBatch(ctx context.Context, people []*Person) []BatchCreateResult
+	GetByID(ctx context.Context, id int64) (*Person, error)
+	GetEnrichmentJob(ctx context.Context, personID int64) (*EnrichmentJob, error)
+	GetEnrichmentHistory(ctx context.Context, personID int64, limit int) ([]*EnrichmentLogEntry, error)
+	GetAll(ctx context.Context, filter *Filter, page, limit int) ([]*Person, int, error)
+	Search(ctx context.Context, query *SearchQuery) ([]*Person, int, error)
+	FuzzySearch(ctx context.Context, query *FuzzyQuery, filter *Filter, page, limit int) ([]*ScoredPerson, int, error)
+	Suggest(ctx context.Context, field FilterField, prefix string, limit int) ([]*Suggestion, error)
+	Update(ctx context.Context, person *Person) error
+	Delete(ctx context.Context, id int64) error
+	Reenrich(ctx context.Context, id int64) (*Person, *EnrichmentReport, error)
+	ReenrichMany(ctx context.Context, filter *Filter, limit int) (*RefreshReport, error)
+	RefreshStale(ctx context.Context, maxAge time.Duration, limit int) (*RefreshReport, error)
+	LastRefreshReport() *RefreshReport
+	GetReviewQueue(ctx context.Context, page, limit int) ([]*Person, int, error)
+	ResolveReview(ctx context.Context, id int64, field, action, value string) (*Person, error)
 }
 
 type ExternalAPIClient interface {
-	GetAge(name string) (int, error)
-	GetGender(name string) (string, error)
-	GetNationality(name string) (string, error)
-	EnrichPerson(name string) (int, string, string, error)
+	GetAge(ctx context.Context, name, countryID string) (*AgePrediction, error)
+	GetGender(ctx context.Context, name, countryID string) (*GenderPrediction, error)
+	GetNationality(ctx context.Context, name string) (*NationalityPrediction, error)
+	EnrichPerson(ctx context.Context, name, countryID string) (*Enrichment, error)
+	EnrichPeople(ctx context.Context, names []string, countryID string, skip ...string) map[string]*Enrichment
+}
+
+type GenderPredictor interface {
+	GetGender(ctx context.Context, name, countryID string) (*GenderPrediction, error)
+}
+
+type NationalityPredictor interface {
+	GetNationality(ctx context.Context, name string) (*NationalityPrediction, error)
+}
+
+type NameNormalizer interface {
+	Normalize(name string) string
+	Phonetic(name string) string
+}
+
+type BatchCreateResult struct {
+	Person *Person
+	Err    error
 }
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+const BatchSize = 10
+
+func (c *ExternalClient) EnrichPeople(ctx context.Context, names []string, countryID string, skip ...string) map[string]*entity.Enrichment {
+	unique := uniqueNames(names)
+	enriched := make(map[string]*entity.Enrichment, len(unique))
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for start := 0; start < len(unique); start += BatchSize {
+		chunk := unique[start:min(start+BatchSize, len(unique))]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			results := c.enrichChunk(ctx, chunk, countryID, skip)
+
+			mu.Lock()
+			defer mu.Unlock()
+			for i, key := range chunk {
+				enriched[key] = results[i]
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	results := make(map[string]*entity.Enrichment, len(names))
+	for _, name := range names {
+		results[name] = enriched[normalizeName(name)]
+	}
+
+	return results
+}
+
+func (c *ExternalClient) enrichChunk(ctx context.Context, names []string, countryID string, skip []string) []*entity.Enrichment {
+	var (
+		ages          []AgifyResponse
+		genders       []GenderizeResponse
+		nationalities []NationalizeResponse
+		ageErr        error
+		genderErr     error
+		nationErr     error
+		err           error
+		wg            sync.WaitGroup
+	)
+
+	fetchAge := !slices.Contains(skip, entity.FieldAge)
+	fetchGender := !slices.Contains(skip, entity.FieldGender)
+	fetchNationality := !slices.Contains(skip, entity.FieldNationality)
+
+	if fetchAge {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ageErr = c.fetch(ctx, c.agify, names, c.agify.batchURL(names, countryID), &ages)
+		}()
+	}
+
+	if fetchGender {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			genderErr = c.fetch(ctx, c.genderize, names, c.genderize.batchURL(names, countryID), &genders)
+		}()
+	}
+
+	if fetchNationality {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nationErr = c.fetch(ctx, c.nationalize, names, c.nationalize.batchURL(names, ""), &nationalities)
+		}()
+	}
+
+	wg.Wait()
+
+	if fetchAge && ageErr == nil && len(ages) != len(names) {
+		ageErr = fmt.Errorf("expected %d results, got %d", len(names), len(ages))
+	}
+	if fetchGender && genderErr == nil && len(genders) != len(names) {
+		genderErr = fmt.Errorf("expected %d results, got %d", len(names), len(genders))
+	}
+	if fetchNationality && nationErr == nil && len(nationalities) != len(names) {
+		nationErr = fmt.Errorf("expected %d results, got %d", len(names), len(nationalities))
+	}
+
+	results := make([]*entity.Enrichment, len(names))
+	for i := range names {
+		enrichment := &entity.Enrichment{}
+
+		if ageErr != nil {
+			enrichment.SetError(entity.FieldAge, fmt.Errorf("failed to get age: %w", ageErr))
+		} else if fetchAge {
+			if enrichment.Age, err = ages[i].prediction(); err != nil {
+				enrichment.SetError(entity.FieldAge, err)
+			}
+		}
+
+		if genderErr != nil {
+			enrichment.SetError(entity.FieldGender, fmt.Errorf("failed to get gender: %w", genderErr))
+		} else if fetchGender {
+			if enrichment.Gender, err = genders[i].prediction(); err != nil {
+				enrichment.SetError(entity.FieldGender, err)
+			}
+		}
+
+		if nationErr != nil {
+			enrichment.SetError(entity.FieldNationality, fmt.Errorf("failed to get nationality: %w", nationErr))
+		} else if fetchNationality {
+			if enrichment.Nationality, err = nationalities[i].prediction(); err != nil {
+				enrichment.SetError(entity.FieldNationality, err)
+			}
+		}
+
+		results[i] = enrichment
+	}
+
+	return results
+}
+
+func uniqueNames(names []string) []string {
+	seen := make(map[string]struct{}, len(names))
+	unique := make([]string, 0, len(names))
+
+	for _, name := range names {
+		key := normalizeName(name)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, key)
+	}
+
+	return unique
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
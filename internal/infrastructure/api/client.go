@@ -1,39 +1,148 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
-	"sync"
+	"net/url"
 	"time"
+
+	"Name_IQ_Finder/internal/entity"
+	"Name_IQ_Finder/internal/infrastructure/resilience"
+	"Name_IQ_Finder/internal/logger"
 )
 
 const (
-	AgifyURL       = "https://api.agify.io/?name=%s"
-	GenderizeURL   = "https://api.genderize.io/?name=%s"
-	NationalizeURL = "https://api.nationalize.io/?name=%s"
+	AgifyBaseURL       = "https://api.agify.io/"
+	GenderizeBaseURL   = "https://api.genderize.io/"
+	NationalizeBaseURL = "https://api.nationalize.io/"
 )
 
+const maxResponseSize = 1 << 20
+
+const (
+	ProviderAgify       = "agify"
+	ProviderGenderize   = "genderize"
+	ProviderNationalize = "nationalize"
+)
+
+type ProviderPolicy struct {
+	BaseURL          string
+	APIKey           string
+	DailyLimit       int
+	Retry            resilience.RetryPolicy
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+type Policies struct {
+	Agify       ProviderPolicy
+	Genderize   ProviderPolicy
+	Nationalize ProviderPolicy
+}
+
+type provider struct {
+	name       string
+	baseURL    string
+	apiKey     string
+	dailyLimit int
+	retry      resilience.RetryPolicy
+	breaker    *resilience.CircuitBreaker
+}
+
+func newProvider(name, defaultBaseURL string, policy ProviderPolicy) *provider {
+	baseURL := policy.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	return &provider{
+		name:       name,
+		baseURL:    baseURL,
+		apiKey:     policy.APIKey,
+		dailyLimit: policy.DailyLimit,
+		retry:      policy.Retry,
+		breaker:    resilience.NewCircuitBreaker(policy.BreakerThreshold, policy.BreakerCooldown),
+	}
+}
+
+func (p *provider) lookupURL(name, countryID string) string {
+	query := url.Values{"name": {name}}
+	if countryID != "" {
+		query.Set("country_id", countryID)
+	}
+
+	return p.baseURL + "?" + query.Encode()
+}
+
+func (p *provider) batchURL(names []string, countryID string) string {
+	query := url.Values{"name[]": names}
+	if countryID != "" {
+		query.Set("country_id", countryID)
+	}
+
+	return p.baseURL + "?" + query.Encode()
+}
+
+func redactURL(err error, lookupURL string) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = lookupURL
+	}
+
+	return err
+}
+
+func (p *provider) withAPIKey(lookupURL string) string {
+	if p.apiKey == "" {
+		return lookupURL
+	}
+
+	return lookupURL + "&" + url.Values{"apikey": {p.apiKey}}.Encode()
+}
+
 type ExternalClient struct {
-	httpClient *http.Client
+	httpClient  *http.Client
+	inflight    *resilience.Coalescer[fetchResult]
+	limiter     *resilience.Limiter
+	quotas      entity.QuotaRepository
+	logger      *logger.Logger
+	agify       *provider
+	genderize   *provider
+	nationalize *provider
+}
+
+type fetchResult struct {
+	body    []byte
+	entries []entity.EnrichmentLogEntry
 }
 
-func NewExternalClient() *ExternalClient {
+func NewExternalClient(timeout time.Duration, maxConcurrency int, policies Policies, quotas entity.QuotaRepository, logger *logger.Logger) *ExternalClient {
 	return &ExternalClient{
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout: timeout,
 		},
+		inflight:    resilience.NewCoalescer[fetchResult](),
+		limiter:     resilience.NewLimiter(maxConcurrency),
+		quotas:      quotas,
+		logger:      logger,
+		agify:       newProvider(ProviderAgify, AgifyBaseURL, policies.Agify),
+		genderize:   newProvider(ProviderGenderize, GenderizeBaseURL, policies.Genderize),
+		nationalize: newProvider(ProviderNationalize, NationalizeBaseURL, policies.Nationalize),
 	}
 }
 
 type AgifyResponse struct {
-	Age   int    `json:"age"`
+	Age   *int   `json:"age"`
 	Count int    `json:"count"`
 	Name  string `json:"name"`
 }
 
 type GenderizeResponse struct {
-	Gender      string  `json:"gender"`
+	Gender      *string `json:"gender"`
 	Probability float64 `json:"probability"`
 	Count       int     `json:"count"`
 	Name        string  `json:"name"`
@@ -41,118 +150,279 @@ type GenderizeResponse struct {
 
 type NationalizeResponse struct {
 	Name    string `json:"name"`
+	Count   int    `json:"count"`
 	Country []struct {
 		CountryID   string  `json:"country_id"`
 		Probability float64 `json:"probability"`
 	} `json:"country"`
 }
 
-func (c *ExternalClient) GetAge(name string) (int, error) {
-	url := fmt.Sprintf(AgifyURL, name)
-
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get age: %w", err)
+func (r AgifyResponse) prediction() (*entity.AgePrediction, error) {
+	if r.Age == nil {
+		return nil, entity.ErrNoPrediction
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("failed to get age: status code %d", resp.StatusCode)
-	}
+	return &entity.AgePrediction{
+		Age:   *r.Age,
+		Count: r.Count,
+	}, nil
+}
 
-	var agifyResp AgifyResponse
-	if err := json.NewDecoder(resp.Body).Decode(&agifyResp); err != nil {
-		return 0, fmt.Errorf("failed to decode age response: %w", err)
+func (r GenderizeResponse) prediction() (*entity.GenderPrediction, error) {
+	if r.Gender == nil {
+		return nil, entity.ErrNoPrediction
 	}
 
-	return agifyResp.Age, nil
+	return &entity.GenderPrediction{
+		Gender:      *r.Gender,
+		Probability: r.Probability,
+		Count:       r.Count,
+	}, nil
 }
 
-func (c *ExternalClient) GetGender(name string) (string, error) {
-	url := fmt.Sprintf(GenderizeURL, name)
+func (r NationalizeResponse) prediction() (*entity.NationalityPrediction, error) {
+	if len(r.Country) == 0 {
+		return nil, entity.ErrNoPrediction
+	}
 
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return "", fmt.Errorf("failed to get gender: %w", err)
+	countries := make([]entity.CountryProbability, len(r.Country))
+	for i, country := range r.Country {
+		countries[i] = entity.CountryProbability{
+			CountryID:   country.CountryID,
+			Probability: country.Probability,
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to get gender: status code %d", resp.StatusCode)
+	return &entity.NationalityPrediction{
+		Countries: countries,
+		Count:     r.Count,
+	}, nil
+}
+
+func (c *ExternalClient) GetAge(ctx context.Context, name, countryID string) (*entity.AgePrediction, error) {
+	var agifyResp AgifyResponse
+	if err := c.fetch(ctx, c.agify, []string{name}, c.agify.lookupURL(name, countryID), &agifyResp); err != nil {
+		return nil, fmt.Errorf("failed to get age: %w", err)
 	}
 
+	return agifyResp.prediction()
+}
+
+func (c *ExternalClient) GetGender(ctx context.Context, name, countryID string) (*entity.GenderPrediction, error) {
 	var genderizeResp GenderizeResponse
-	if err := json.NewDecoder(resp.Body).Decode(&genderizeResp); err != nil {
-		return "", fmt.Errorf("failed to decode gender response: %w", err)
+	if err := c.fetch(ctx, c.genderize, []string{name}, c.genderize.lookupURL(name, countryID), &genderizeResp); err != nil {
+		return nil, fmt.Errorf("failed to get gender: %w", err)
 	}
 
-	return genderizeResp.Gender, nil
+	return genderizeResp.prediction()
 }
 
-func (c *ExternalClient) GetNationality(name string) (string, error) {
-	url := fmt.Sprintf(NationalizeURL, name)
+func (c *ExternalClient) GetNationality(ctx context.Context, name string) (*entity.NationalityPrediction, error) {
+	var nationalizeResp NationalizeResponse
+	if err := c.fetch(ctx, c.nationalize, []string{name}, c.nationalize.lookupURL(name, ""), &nationalizeResp); err != nil {
+		return nil, fmt.Errorf("failed to get nationality: %w", err)
+	}
 
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return "", fmt.Errorf("failed to get nationality: %w", err)
+	return nationalizeResp.prediction()
+}
+
+func (c *ExternalClient) EnrichPerson(ctx context.Context, name, countryID string) (*entity.Enrichment, error) {
+	return Enrich(ctx, c, name, countryID)
+}
+
+func (c *ExternalClient) ProviderStatuses() []entity.ProviderStatus {
+	statuses := make([]entity.ProviderStatus, 0, 3)
+	for _, p := range []*provider{c.agify, c.genderize, c.nationalize} {
+		snapshot := p.breaker.Snapshot()
+
+		status := entity.ProviderStatus{
+			Provider: p.name,
+			State:    string(snapshot.State),
+			Failures: snapshot.Failures,
+		}
+		if !snapshot.OpenedAt.IsZero() {
+			status.OpenedAt = &snapshot.OpenedAt
+		}
+
+		statuses = append(statuses, status)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to get nationality: status code %d", resp.StatusCode)
+	return statuses
+}
+
+type statusError struct {
+	code       int
+	retryAfter time.Duration
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("status code %d", e.code)
+}
+
+func (e *statusError) retryable() bool {
+	return e.rateLimited() || e.code >= http.StatusInternalServerError
+}
+
+func (e *statusError) rateLimited() bool {
+	return e.code == http.StatusTooManyRequests
+}
+
+func (e *statusError) unauthorized() bool {
+	return e.code == http.StatusUnauthorized || e.code == http.StatusForbidden
+}
+
+func (c *ExternalClient) fetch(ctx context.Context, p *provider, names []string, url string, dst interface{}) error {
+	result, err := c.inflight.Do(ctx, p.name+" "+url, func(ctx context.Context) (fetchResult, error) {
+		callCtx, recorder := entity.WithCallRecorder(ctx)
+		body, err := c.call(callCtx, p, names, url)
+		return fetchResult{body: body, entries: recorder.Entries()}, err
+	})
+
+	for _, entry := range result.entries {
+		entity.RecordCall(ctx, entry)
 	}
 
-	var nationalizeResp NationalizeResponse
-	if err := json.NewDecoder(resp.Body).Decode(&nationalizeResp); err != nil {
-		return "", fmt.Errorf("failed to decode nationality response: %w", err)
+	if err != nil {
+		return err
 	}
 
-	if len(nationalizeResp.Country) == 0 {
-		return "unknown", nil
+	if err := json.Unmarshal(result.body, dst); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return nationalizeResp.Country[0].CountryID, nil
+	return nil
 }
 
-func (c *ExternalClient) EnrichPerson(name string) (int, string, string, error) {
+func (c *ExternalClient) call(ctx context.Context, p *provider, names []string, url string) ([]byte, error) {
+	if err := p.breaker.Allow(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", entity.ErrProviderUnavailable, p.name, err)
+	}
+
 	var (
-		age         int
-		gender      string
-		nationality string
-		ageErr      error
-		genderErr   error
-		nationErr   error
-		wg          sync.WaitGroup
+		err       error
+		statusErr *statusError
 	)
+	for attempt := 0; ; attempt++ {
+		var body []byte
+		body, err = c.get(ctx, p, names, url)
+		if err == nil {
+			p.breaker.Success()
+			return body, nil
+		}
+
+		if ctx.Err() != nil {
+			p.breaker.Release()
+			return nil, ctx.Err()
+		}
+
+		if errors.Is(err, entity.ErrQuotaExhausted) {
+			p.breaker.Release()
+			return nil, fmt.Errorf("%w: %s: %w", entity.ErrProviderUnavailable, p.name, err)
+		}
+
+		statusErr = nil
+		isStatusErr := errors.As(err, &statusErr)
+		if isStatusErr && statusErr.unauthorized() {
+			p.breaker.Failure()
+			return nil, fmt.Errorf("%w: %s: %w", entity.ErrProviderUnavailable, p.name, err)
+		}
+		if isStatusErr && !statusErr.retryable() {
+			p.breaker.Release()
+			return nil, err
+		}
 
-	wg.Add(3)
+		if attempt >= p.retry.MaxRetries {
+			break
+		}
 
-	go func() {
-		defer wg.Done()
-		age, ageErr = c.GetAge(name)
-	}()
+		delay := p.retry.Backoff(attempt)
+		if isStatusErr && statusErr.retryAfter > 0 {
+			if p.retry.MaxDelay > 0 && statusErr.retryAfter > p.retry.MaxDelay {
+				break
+			}
+			delay = max(delay, statusErr.retryAfter)
+		}
 
-	go func() {
-		defer wg.Done()
-		gender, genderErr = c.GetGender(name)
-	}()
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			p.breaker.Release()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if statusErr != nil && statusErr.rateLimited() {
+		p.breaker.Release()
+		if statusErr.retryAfter > 0 {
+			err = &entity.QuotaError{Provider: p.name, ResetAt: time.Now().Add(statusErr.retryAfter)}
+		}
+		return nil, fmt.Errorf("%w: %s: %w", entity.ErrProviderUnavailable, p.name, err)
+	}
+
+	p.breaker.Failure()
+	return nil, fmt.Errorf("%w: %s: %w", entity.ErrProviderUnavailable, p.name, err)
+}
+
+func (c *ExternalClient) get(ctx context.Context, p *provider, names []string, url string) ([]byte, error) {
+	if err := c.reserve(ctx, p, len(names)); err != nil {
+		return nil, err
+	}
 
-	go func() {
-		defer wg.Done()
-		nationality, nationErr = c.GetNationality(name)
-	}()
+	if err := c.limiter.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.limiter.Release()
 
-	wg.Wait()
+	entry := entity.EnrichmentLogEntry{
+		Provider:   p.name,
+		RequestURL: url,
+		Names:      names,
+		CreatedAt:  time.Now().Format(time.RFC3339),
+	}
 
-	if ageErr != nil {
-		return 0, "", "", fmt.Errorf("failed to get age: %w", ageErr)
+	start := time.Now()
+	body, err := c.do(ctx, p, url, &entry)
+	entry.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		entry.Error = err.Error()
 	}
-	if genderErr != nil {
-		return 0, "", "", fmt.Errorf("failed to get gender: %w", genderErr)
+
+	entity.RecordCall(ctx, entry)
+	return body, err
+}
+
+func (c *ExternalClient) do(ctx context.Context, p *provider, url string, entry *entity.EnrichmentLogEntry) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.withAPIKey(url), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
 	}
-	if nationErr != nil {
-		return 0, "", "", fmt.Errorf("failed to get nationality: %w", nationErr)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, redactURL(err, url)
+	}
+	defer resp.Body.Close()
+
+	entry.StatusCode = resp.StatusCode
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	entry.ResponseBody = string(body)
+
+	c.observe(ctx, p, resp)
+
+	if resp.StatusCode != http.StatusOK {
+		retryAfter, _ := resilience.ParseRetryAfter(resp.Header.Get("Retry-After"))
+		return nil, &statusError{code: resp.StatusCode, retryAfter: retryAfter}
+	}
+
+	if !json.Valid(body) {
+		return nil, errors.New("failed to decode response: invalid JSON")
 	}
 
-	return age, gender, nationality, nil
+	return body, nil
 }
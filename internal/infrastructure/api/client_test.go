@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+	"Name_IQ_Finder/internal/infrastructure/resilience"
+)
+
+func TestCallBreakerAccounting(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		wantState       resilience.State
+		wantUnavailable bool
+	}{
+		{name: "bad api key opens the circuit", status: http.StatusUnauthorized, wantState: resilience.StateOpen, wantUnavailable: true},
+		{name: "forbidden opens the circuit", status: http.StatusForbidden, wantState: resilience.StateOpen, wantUnavailable: true},
+		{name: "server errors open the circuit", status: http.StatusBadGateway, wantState: resilience.StateOpen, wantUnavailable: true},
+		{name: "caller errors leave the circuit closed", status: http.StatusUnprocessableEntity, wantState: resilience.StateClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewExternalClient(time.Second, 0, Policies{
+				Agify: ProviderPolicy{BaseURL: server.URL + "/", BreakerThreshold: 2, BreakerCooldown: time.Minute},
+			}, nil, nil)
+
+			var err error
+			for range 2 {
+				_, err = client.GetAge(context.Background(), "ivan", "")
+			}
+
+			if got := errors.Is(err, entity.ErrProviderUnavailable); got != tt.wantUnavailable {
+				t.Errorf("error = %v, unavailable = %v, want %v", err, got, tt.wantUnavailable)
+			}
+			if state := client.agify.breaker.Snapshot().State; state != tt.wantState {
+				t.Errorf("breaker state = %s, want %s", state, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestCallRateLimitKeepsBreakerClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewExternalClient(time.Second, 0, Policies{
+		Agify: ProviderPolicy{
+			BaseURL:          server.URL + "/",
+			Retry:            resilience.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second},
+			BreakerThreshold: 1,
+			BreakerCooldown:  time.Minute,
+		},
+	}, nil, nil)
+
+	_, err := client.GetAge(context.Background(), "ivan", "")
+
+	var quotaErr *entity.QuotaError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("error = %v, want a quota error", err)
+	}
+	if wait := time.Until(quotaErr.ResetAt); wait < 59*time.Minute || wait > time.Hour {
+		t.Errorf("reset in %s, want about an hour", wait)
+	}
+	if state := client.agify.breaker.Snapshot().State; state != resilience.StateClosed {
+		t.Errorf("breaker state = %s, want %s", state, resilience.StateClosed)
+	}
+}
+
+func TestCallHidesAPIKeyOnNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer server.Close()
+
+	const apiKey = "secret-key"
+	client := NewExternalClient(time.Second, 0, Policies{
+		Agify: ProviderPolicy{BaseURL: server.URL + "/", APIKey: apiKey},
+	}, nil, nil)
+
+	ctx, recorder := entity.WithCallRecorder(context.Background())
+	_, err := client.GetAge(ctx, "ivan", "")
+	if err == nil {
+		t.Fatal("expected an error from a closed connection")
+	}
+	if strings.Contains(err.Error(), apiKey) {
+		t.Errorf("error leaks the api key: %v", err)
+	}
+
+	entries := recorder.Entries()
+	if len(entries) == 0 {
+		t.Fatal("expected the call to be recorded")
+	}
+	for _, entry := range entries {
+		if entry.Error == "" {
+			t.Errorf("entry error is empty")
+		}
+		if strings.Contains(entry.Error, apiKey) || strings.Contains(entry.RequestURL, apiKey) {
+			t.Errorf("recorded entry leaks the api key: %+v", entry)
+		}
+	}
+}
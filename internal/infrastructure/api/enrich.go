@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"sync"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+func Enrich(ctx context.Context, client entity.ExternalAPIClient, name, countryID string) (*entity.Enrichment, error) {
+	var (
+		enrichment entity.Enrichment
+		ageErr     error
+		genderErr  error
+		nationErr  error
+		wg         sync.WaitGroup
+	)
+
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		enrichment.Age, ageErr = client.GetAge(ctx, name, countryID)
+	}()
+
+	go func() {
+		defer wg.Done()
+		enrichment.Gender, genderErr = client.GetGender(ctx, name, countryID)
+	}()
+
+	go func() {
+		defer wg.Done()
+		enrichment.Nationality, nationErr = client.GetNationality(ctx, name)
+	}()
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if ageErr != nil {
+		enrichment.SetError(entity.FieldAge, ageErr)
+	}
+	if genderErr != nil {
+		enrichment.SetError(entity.FieldGender, genderErr)
+	}
+	if nationErr != nil {
+		enrichment.SetError(entity.FieldNationality, nationErr)
+	}
+
+	return &enrichment, nil
+}
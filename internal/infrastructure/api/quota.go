@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+	"Name_IQ_Finder/internal/infrastructure/resilience"
+)
+
+func (c *ExternalClient) reserve(ctx context.Context, p *provider, units int) error {
+	if c.quotas == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	if p.dailyLimit > 0 && units > p.dailyLimit {
+		return &entity.QuotaError{Provider: p.name, ResetAt: nextDay(now)}
+	}
+
+	reserved, usage, err := c.quotas.Reserve(ctx, p.name, quotaDay(now), units, p.dailyLimit)
+	if err != nil {
+		c.logger.Warn("Failed to reserve %s quota, letting the request through: %v", p.name, err)
+		return nil
+	}
+	if reserved {
+		return nil
+	}
+
+	resetAt := nextDay(now)
+	if usage.ResetAt != nil && usage.ResetAt.After(now) && (p.dailyLimit == 0 || usage.Used+units <= p.dailyLimit) {
+		resetAt = *usage.ResetAt
+	}
+
+	return &entity.QuotaError{Provider: p.name, ResetAt: resetAt}
+}
+
+func (c *ExternalClient) observe(ctx context.Context, p *provider, resp *http.Response) {
+	if c.quotas == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+
+	remaining, err := strconv.Atoi(resp.Header.Get("X-Rate-Limit-Remaining"))
+	if err != nil {
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return
+		}
+		remaining = 0
+	}
+
+	resetAt := nextDay(now)
+	if reset, ok := resilience.ParseRetryAfter(resp.Header.Get("X-Rate-Limit-Reset")); ok {
+		resetAt = now.Add(reset)
+	} else if retryAfter, ok := resilience.ParseRetryAfter(resp.Header.Get("Retry-After")); ok && retryAfter > 0 {
+		resetAt = now.Add(retryAfter)
+	}
+
+	if err := c.quotas.Observe(ctx, p.name, quotaDay(now), remaining, resetAt); err != nil {
+		c.logger.Warn("Failed to record %s quota headers: %v", p.name, err)
+	}
+}
+
+func (c *ExternalClient) QuotaUsage(ctx context.Context) ([]entity.QuotaUsage, error) {
+	day := quotaDay(time.Now().UTC())
+
+	stored := map[string]*entity.QuotaUsage{}
+	if c.quotas != nil {
+		usages, err := c.quotas.Usage(ctx, day)
+		if err != nil {
+			return nil, err
+		}
+		for _, usage := range usages {
+			stored[usage.Provider] = usage
+		}
+	}
+
+	result := make([]entity.QuotaUsage, 0, 3)
+	for _, p := range []*provider{c.agify, c.genderize, c.nationalize} {
+		usage := entity.QuotaUsage{Provider: p.name, Day: day}
+		if s, ok := stored[p.name]; ok {
+			usage = *s
+		}
+		usage.Limit = p.dailyLimit
+
+		result = append(result, usage)
+	}
+
+	return result, nil
+}
+
+func quotaDay(now time.Time) string {
+	return now.Format(time.DateOnly)
+}
+
+func nextDay(now time.Time) time.Time {
+	return now.Truncate(24 * time.Hour).Add(24 * time.Hour)
+}
@@ -0,0 +1,325 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+	"Name_IQ_Finder/internal/infrastructure/api"
+	"Name_IQ_Finder/internal/logger"
+)
+
+var negativePayload = []byte("null")
+
+type TTLs struct {
+	Age         time.Duration
+	Gender      time.Duration
+	Nationality time.Duration
+	Negative    time.Duration
+}
+
+type Client struct {
+	next        entity.ExternalAPIClient
+	memory      *LRU
+	shared      *PostgresStore
+	ttls        map[string]time.Duration
+	negativeTTL time.Duration
+	logger      *logger.Logger
+
+	memoryHits atomic.Int64
+	sharedHits atomic.Int64
+	misses     atomic.Int64
+}
+
+func NewClient(next entity.ExternalAPIClient, memory *LRU, shared *PostgresStore, ttls TTLs, logger *logger.Logger) *Client {
+	return &Client{
+		next:   next,
+		memory: memory,
+		shared: shared,
+		ttls: map[string]time.Duration{
+			api.ProviderAgify:       ttls.Age,
+			api.ProviderGenderize:   ttls.Gender,
+			api.ProviderNationalize: ttls.Nationality,
+		},
+		negativeTTL: ttls.Negative,
+		logger:      logger,
+	}
+}
+
+func (c *Client) GetAge(ctx context.Context, name, countryID string) (*entity.AgePrediction, error) {
+	var age entity.AgePrediction
+	if ok, err := c.load(ctx, localized(api.ProviderAgify, countryID), name, &age); ok {
+		if err != nil {
+			return nil, err
+		}
+		return &age, nil
+	}
+
+	prediction, err := c.next.GetAge(ctx, name, countryID)
+	remember(ctx, c, localized(api.ProviderAgify, countryID), name, prediction, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return prediction, nil
+}
+
+func (c *Client) GetGender(ctx context.Context, name, countryID string) (*entity.GenderPrediction, error) {
+	var gender entity.GenderPrediction
+	if ok, err := c.load(ctx, localized(api.ProviderGenderize, countryID), name, &gender); ok {
+		if err != nil {
+			return nil, err
+		}
+		return &gender, nil
+	}
+
+	prediction, err := c.next.GetGender(ctx, name, countryID)
+	remember(ctx, c, localized(api.ProviderGenderize, countryID), name, prediction, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return prediction, nil
+}
+
+func (c *Client) GetNationality(ctx context.Context, name string) (*entity.NationalityPrediction, error) {
+	var nationality entity.NationalityPrediction
+	if ok, err := c.load(ctx, api.ProviderNationalize, name, &nationality); ok {
+		if err != nil {
+			return nil, err
+		}
+		return &nationality, nil
+	}
+
+	prediction, err := c.next.GetNationality(ctx, name)
+	remember(ctx, c, api.ProviderNationalize, name, prediction, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return prediction, nil
+}
+
+func (c *Client) EnrichPerson(ctx context.Context, name, countryID string) (*entity.Enrichment, error) {
+	return api.Enrich(ctx, c, name, countryID)
+}
+
+func (c *Client) EnrichPeople(ctx context.Context, names []string, countryID string, skip ...string) map[string]*entity.Enrichment {
+	byKey := make(map[string]*entity.Enrichment, len(names))
+	misses := make([]string, 0, len(names))
+
+	for _, name := range names {
+		key := normalizeKey(name)
+		if _, ok := byKey[key]; ok {
+			continue
+		}
+
+		enrichment, ok := c.loadEnrichment(ctx, name, countryID, skip)
+		byKey[key] = enrichment
+		if !ok {
+			misses = append(misses, name)
+		}
+	}
+
+	if len(misses) > 0 {
+		for name, enrichment := range c.next.EnrichPeople(ctx, misses, countryID, skip...) {
+			if enrichment == nil {
+				continue
+			}
+			remember(ctx, c, localized(api.ProviderAgify, countryID), name, enrichment.Age, enrichment.Errors[entity.FieldAge])
+			remember(ctx, c, localized(api.ProviderGenderize, countryID), name, enrichment.Gender, enrichment.Errors[entity.FieldGender])
+			remember(ctx, c, api.ProviderNationalize, name, enrichment.Nationality, enrichment.Errors[entity.FieldNationality])
+			byKey[normalizeKey(name)] = enrichment
+		}
+	}
+
+	results := make(map[string]*entity.Enrichment, len(names))
+	for _, name := range names {
+		if enrichment := byKey[normalizeKey(name)]; enrichment != nil {
+			results[name] = enrichment
+		}
+	}
+
+	return results
+}
+
+func (c *Client) loadEnrichment(ctx context.Context, name, countryID string, skip []string) (*entity.Enrichment, bool) {
+	var (
+		enrichment  entity.Enrichment
+		age         entity.AgePrediction
+		gender      entity.GenderPrediction
+		nationality entity.NationalityPrediction
+	)
+
+	if !slices.Contains(skip, entity.FieldAge) {
+		ok, err := c.load(ctx, localized(api.ProviderAgify, countryID), name, &age)
+		if !ok {
+			return nil, false
+		}
+		if err != nil {
+			enrichment.SetError(entity.FieldAge, err)
+		} else {
+			enrichment.Age = &age
+		}
+	}
+
+	if !slices.Contains(skip, entity.FieldGender) {
+		ok, err := c.load(ctx, localized(api.ProviderGenderize, countryID), name, &gender)
+		if !ok {
+			return nil, false
+		}
+		if err != nil {
+			enrichment.SetError(entity.FieldGender, err)
+		} else {
+			enrichment.Gender = &gender
+		}
+	}
+
+	if !slices.Contains(skip, entity.FieldNationality) {
+		ok, err := c.load(ctx, api.ProviderNationalize, name, &nationality)
+		if !ok {
+			return nil, false
+		}
+		if err != nil {
+			enrichment.SetError(entity.FieldNationality, err)
+		} else {
+			enrichment.Nationality = &nationality
+		}
+	}
+
+	return &enrichment, true
+}
+
+func (c *Client) Invalidate(ctx context.Context, name string) error {
+	name = normalizeKey(name)
+
+	c.memory.DeleteFunc(func(key string) bool {
+		return strings.HasSuffix(key, ":"+name)
+	})
+
+	if c.shared != nil {
+		if err := c.shared.Delete(ctx, name); err != nil {
+			return fmt.Errorf("failed to invalidate %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) Purge(ctx context.Context) error {
+	c.memory.Purge()
+
+	if c.shared != nil {
+		if err := c.shared.Purge(ctx); err != nil {
+			return fmt.Errorf("failed to purge cache: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) Stats() entity.CacheStats {
+	memoryHits := c.memoryHits.Load()
+	sharedHits := c.sharedHits.Load()
+
+	return entity.CacheStats{
+		Hits:       memoryHits + sharedHits,
+		Misses:     c.misses.Load(),
+		MemoryHits: memoryHits,
+		SharedHits: sharedHits,
+		Entries:    c.memory.Len(),
+	}
+}
+
+func (c *Client) load(ctx context.Context, provider, name string, dst interface{}) (bool, error) {
+	name = normalizeKey(name)
+	key := cacheKey(provider, name)
+
+	if payload, ok := c.memory.Get(key); ok {
+		if bytes.Equal(payload, negativePayload) {
+			c.memoryHits.Add(1)
+			return true, entity.ErrNoPrediction
+		}
+		if err := json.Unmarshal(payload, dst); err == nil {
+			c.memoryHits.Add(1)
+			return true, nil
+		}
+		c.memory.Delete(key)
+	}
+
+	if c.shared != nil {
+		payload, expiresAt, ok, err := c.shared.Get(ctx, provider, name)
+		if err != nil {
+			c.logger.Warn("Shared cache lookup failed for %s: %v", key, err)
+		}
+		if ok {
+			if bytes.Equal(payload, negativePayload) {
+				c.memory.Set(key, payload, time.Until(expiresAt))
+				c.sharedHits.Add(1)
+				return true, entity.ErrNoPrediction
+			}
+			if err := json.Unmarshal(payload, dst); err == nil {
+				c.memory.Set(key, payload, time.Until(expiresAt))
+				c.sharedHits.Add(1)
+				return true, nil
+			}
+		}
+	}
+
+	c.misses.Add(1)
+	return false, nil
+}
+
+func remember[T any](ctx context.Context, c *Client, provider, name string, value *T, err error) {
+	switch {
+	case err == nil && value != nil:
+		c.store(ctx, provider, name, value)
+	case errors.Is(err, entity.ErrNoPrediction) && c.negativeTTL > 0:
+		c.storePayload(ctx, provider, name, negativePayload, c.negativeTTL)
+	}
+}
+
+func (c *Client) store(ctx context.Context, provider, name string, value interface{}) {
+	base, _, _ := strings.Cut(provider, "@")
+
+	payload, err := json.Marshal(value)
+	if err != nil {
+		c.logger.Warn("Failed to encode cache entry for %s: %v", cacheKey(provider, normalizeKey(name)), err)
+		return
+	}
+
+	c.storePayload(ctx, provider, name, payload, c.ttls[base])
+}
+
+func (c *Client) storePayload(ctx context.Context, provider, name string, payload []byte, ttl time.Duration) {
+	name = normalizeKey(name)
+	c.memory.Set(cacheKey(provider, name), payload, ttl)
+
+	if c.shared != nil && ttl > 0 {
+		if err := c.shared.Set(ctx, provider, name, payload, ttl); err != nil {
+			c.logger.Warn("Shared cache write failed for %s: %v", cacheKey(provider, name), err)
+		}
+	}
+}
+
+func normalizeKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func localized(provider, countryID string) string {
+	if countryID == "" {
+		return provider
+	}
+	return provider + "@" + strings.ToLower(countryID)
+}
+
+func cacheKey(provider, name string) string {
+	return provider + ":" + name
+}
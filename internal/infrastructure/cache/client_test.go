@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+	"Name_IQ_Finder/internal/logger"
+)
+
+type countingClient struct {
+	known   map[string]bool
+	lookups map[string]int
+	batched [][]string
+}
+
+func newCountingClient(known ...string) *countingClient {
+	c := &countingClient{known: make(map[string]bool), lookups: make(map[string]int)}
+	for _, name := range known {
+		c.known[name] = true
+	}
+	return c
+}
+
+func (c *countingClient) GetAge(ctx context.Context, name, countryID string) (*entity.AgePrediction, error) {
+	c.lookups[name]++
+	if !c.known[normalizeKey(name)] {
+		return nil, entity.ErrNoPrediction
+	}
+	return &entity.AgePrediction{Age: 30, Count: 10}, nil
+}
+
+func (c *countingClient) GetGender(ctx context.Context, name, countryID string) (*entity.GenderPrediction, error) {
+	if !c.known[normalizeKey(name)] {
+		return nil, entity.ErrNoPrediction
+	}
+	return &entity.GenderPrediction{Gender: "male", Probability: 0.9, Count: 10}, nil
+}
+
+func (c *countingClient) GetNationality(ctx context.Context, name string) (*entity.NationalityPrediction, error) {
+	if !c.known[normalizeKey(name)] {
+		return nil, entity.ErrNoPrediction
+	}
+	return &entity.NationalityPrediction{Countries: []entity.CountryProbability{{CountryID: "RU", Probability: 0.7}}, Count: 10}, nil
+}
+
+func (c *countingClient) EnrichPerson(ctx context.Context, name, countryID string) (*entity.Enrichment, error) {
+	return c.EnrichPeople(ctx, []string{name}, countryID)[name], nil
+}
+
+func (c *countingClient) EnrichPeople(ctx context.Context, names []string, countryID string, skip ...string) map[string]*entity.Enrichment {
+	c.batched = append(c.batched, names)
+
+	results := make(map[string]*entity.Enrichment, len(names))
+	for _, name := range names {
+		var (
+			enrichment entity.Enrichment
+			err        error
+		)
+		if enrichment.Age, err = c.GetAge(ctx, name, countryID); err != nil {
+			enrichment.SetError(entity.FieldAge, err)
+		}
+		if enrichment.Gender, err = c.GetGender(ctx, name, countryID); err != nil {
+			enrichment.SetError(entity.FieldGender, err)
+		}
+		if enrichment.Nationality, err = c.GetNationality(ctx, name); err != nil {
+			enrichment.SetError(entity.FieldNationality, err)
+		}
+		results[name] = enrichment.Without(skip...)
+	}
+
+	return results
+}
+
+func newTestClient(next entity.ExternalAPIClient, negativeTTL time.Duration) *Client {
+	return NewClient(next, NewLRU(100), nil, TTLs{
+		Age:         time.Hour,
+		Gender:      time.Hour,
+		Nationality: time.Hour,
+		Negative:    negativeTTL,
+	}, logger.New("error"))
+}
+
+func TestClientCachesMissingPredictions(t *testing.T) {
+	tests := []struct {
+		name        string
+		negativeTTL time.Duration
+		wantLookups int
+	}{
+		{name: "negative results cached", negativeTTL: time.Minute, wantLookups: 1},
+		{name: "negative caching disabled", negativeTTL: 0, wantLookups: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := newCountingClient()
+			client := newTestClient(next, tt.negativeTTL)
+
+			for i := 0; i < 3; i++ {
+				if _, err := client.GetAge(context.Background(), "Zzyzx", ""); !errors.Is(err, entity.ErrNoPrediction) {
+					t.Fatalf("GetAge() error = %v, want ErrNoPrediction", err)
+				}
+			}
+
+			if got := next.lookups["Zzyzx"]; got != tt.wantLookups {
+				t.Errorf("provider lookups = %d, want %d", got, tt.wantLookups)
+			}
+		})
+	}
+}
+
+func TestClientEnrichPeopleDeduplicatesByNormalizedName(t *testing.T) {
+	next := newCountingClient("ivan")
+	client := newTestClient(next, time.Minute)
+
+	names := []string{"Ivan", "ivan", " IVAN ", "Zzyzx"}
+	results := client.EnrichPeople(context.Background(), names, "")
+
+	if len(next.batched) != 1 || len(next.batched[0]) != 2 {
+		t.Fatalf("provider batches = %v, want one batch of 2 names", next.batched)
+	}
+	for _, name := range names[:3] {
+		if results[name] == nil || results[name].Age == nil {
+			t.Errorf("result for %q = %+v, want a prediction", name, results[name])
+		}
+	}
+	if err := results["Zzyzx"].Errors[entity.FieldAge]; !errors.Is(err, entity.ErrNoPrediction) {
+		t.Errorf("unknown name age error = %v, want ErrNoPrediction", err)
+	}
+
+	again := client.EnrichPeople(context.Background(), names, "")
+	if len(next.batched) != 1 {
+		t.Errorf("second call reached the provider with %v", next.batched[1:])
+	}
+	if err := again["Zzyzx"].Errors[entity.FieldNationality]; !errors.Is(err, entity.ErrNoPrediction) {
+		t.Errorf("cached unknown name nationality error = %v, want ErrNoPrediction", err)
+	}
+}
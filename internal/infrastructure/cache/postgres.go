@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{
+		db: db,
+	}
+}
+
+func (s *PostgresStore) Get(ctx context.Context, provider, name string) ([]byte, time.Time, bool, error) {
+	query := `
+		SELECT payload, expires_at
+		FROM enrichment_cache
+		WHERE provider = $1 AND name = $2 AND expires_at > NOW()
+	`
+
+	var (
+		payload   []byte
+		expiresAt time.Time
+	)
+	err := s.db.QueryRowContext(ctx, query, provider, name).Scan(&payload, &expiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, time.Time{}, false, nil
+		}
+		return nil, time.Time{}, false, fmt.Errorf("failed to get cache entry: %w", err)
+	}
+
+	return payload, expiresAt, true, nil
+}
+
+func (s *PostgresStore) Set(ctx context.Context, provider, name string, payload []byte, ttl time.Duration) error {
+	query := `
+		INSERT INTO enrichment_cache (provider, name, payload, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, name)
+		DO UPDATE SET payload = EXCLUDED.payload, expires_at = EXCLUDED.expires_at
+	`
+
+	_, err := s.db.ExecContext(ctx, query, provider, name, string(payload), time.Now().Add(ttl))
+	if err != nil {
+		return fmt.Errorf("failed to set cache entry: %w", err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) Delete(ctx context.Context, name string) error {
+	query := `
+		DELETE FROM enrichment_cache
+		WHERE name = $1
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, name); err != nil {
+		return fmt.Errorf("failed to delete cache entries: %w", err)
+	}
+
+	return nil
+}
+
+func (s *PostgresStore) Purge(ctx context.Context) error {
+	query := `
+		DELETE FROM enrichment_cache
+	`
+
+	if _, err := s.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to purge cache: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package composite
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+const (
+	StrategyFallback = "fallback"
+	StrategyEnsemble = "ensemble"
+)
+
+type SourceSpec struct {
+	Name   string
+	Weight float64
+}
+
+func ParseSources(specs []string) ([]SourceSpec, error) {
+	sources := make([]SourceSpec, 0, len(specs))
+	seen := make(map[string]struct{}, len(specs))
+
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		name, rawWeight, hasWeight := strings.Cut(spec, ":")
+		source := SourceSpec{
+			Name:   strings.ToLower(strings.TrimSpace(name)),
+			Weight: 1,
+		}
+
+		if hasWeight {
+			weight, err := strconv.ParseFloat(strings.TrimSpace(rawWeight), 64)
+			if err != nil || weight <= 0 {
+				return nil, fmt.Errorf("invalid weight in %q: must be a positive number", spec)
+			}
+			source.Weight = weight
+		}
+
+		if _, ok := seen[source.Name]; ok {
+			return nil, fmt.Errorf("source %q is listed twice", source.Name)
+		}
+		seen[source.Name] = struct{}{}
+
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+type Source struct {
+	Name   string
+	Weight float64
+	Client entity.ExternalAPIClient
+}
+
+type Chain struct {
+	Strategy string
+	Sources  []Source
+}
+
+func NewChain(strategy string, sources []Source) (Chain, error) {
+	switch strategy {
+	case StrategyFallback, StrategyEnsemble:
+	default:
+		return Chain{}, fmt.Errorf("unknown strategy %q", strategy)
+	}
+
+	return Chain{
+		Strategy: strategy,
+		Sources:  sources,
+	}, nil
+}
+
+func (c Chain) index(name string) int {
+	for i, source := range c.Sources {
+		if source.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+type vote[T any] struct {
+	weight     float64
+	prediction *T
+}
+
+func resolve[T any](chain Chain, get func(Source) (*T, error), combine func([]vote[T]) *T) (*T, error) {
+	if chain.Strategy == StrategyEnsemble {
+		return ensemble(chain, get, combine)
+	}
+
+	var errs []error
+	for _, source := range chain.Sources {
+		prediction, err := get(source)
+		if err == nil {
+			return prediction, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", source.Name, err))
+	}
+
+	return nil, chainError(errs)
+}
+
+func ensemble[T any](chain Chain, get func(Source) (*T, error), combine func([]vote[T]) *T) (*T, error) {
+	var (
+		predictions = make([]*T, len(chain.Sources))
+		errs        = make([]error, len(chain.Sources))
+		wg          sync.WaitGroup
+	)
+
+	for i, source := range chain.Sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			predictions[i], errs[i] = get(source)
+		}()
+	}
+
+	wg.Wait()
+
+	var (
+		votes  []vote[T]
+		failed []error
+	)
+	for i, source := range chain.Sources {
+		if errs[i] != nil {
+			failed = append(failed, fmt.Errorf("%s: %w", source.Name, errs[i]))
+			continue
+		}
+		votes = append(votes, vote[T]{weight: source.Weight, prediction: predictions[i]})
+	}
+
+	if len(votes) == 0 {
+		return nil, chainError(failed)
+	}
+
+	return combine(votes), nil
+}
+
+func chainError(errs []error) error {
+	for _, err := range errs {
+		if !errors.Is(err, entity.ErrNoPrediction) {
+			return errors.Join(errs...)
+		}
+	}
+
+	return entity.ErrNoPrediction
+}
@@ -0,0 +1,193 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"Name_IQ_Finder/internal/entity"
+	"Name_IQ_Finder/internal/infrastructure/api"
+)
+
+var errNoResult = errors.New("source returned no result")
+
+type Client struct {
+	age         Chain
+	gender      Chain
+	nationality Chain
+}
+
+func NewClient(age, gender, nationality Chain) *Client {
+	return &Client{
+		age:         age,
+		gender:      gender,
+		nationality: nationality,
+	}
+}
+
+func (c *Client) GetAge(ctx context.Context, name, countryID string) (*entity.AgePrediction, error) {
+	return resolve(c.age, func(source Source) (*entity.AgePrediction, error) {
+		return source.Client.GetAge(ctx, name, countryID)
+	}, combineAge)
+}
+
+func (c *Client) GetGender(ctx context.Context, name, countryID string) (*entity.GenderPrediction, error) {
+	return c.gender.GetGender(ctx, name, countryID)
+}
+
+func (c Chain) GetGender(ctx context.Context, name, countryID string) (*entity.GenderPrediction, error) {
+	return resolve(c, func(source Source) (*entity.GenderPrediction, error) {
+		return source.Client.GetGender(ctx, name, countryID)
+	}, combineGender)
+}
+
+func (c *Client) GetNationality(ctx context.Context, name string) (*entity.NationalityPrediction, error) {
+	return resolve(c.nationality, func(source Source) (*entity.NationalityPrediction, error) {
+		return source.Client.GetNationality(ctx, name)
+	}, combineNationality)
+}
+
+func (c *Client) EnrichPerson(ctx context.Context, name, countryID string) (*entity.Enrichment, error) {
+	return api.Enrich(ctx, c, name, countryID)
+}
+
+func (c *Client) EnrichPeople(ctx context.Context, names []string, countryID string, skip ...string) map[string]*entity.Enrichment {
+	fetched := c.fetch(ctx, names, countryID, skip)
+	results := make(map[string]*entity.Enrichment, len(names))
+
+	for _, name := range names {
+		if _, ok := results[name]; ok {
+			continue
+		}
+
+		lookup := func(source Source) (*entity.Enrichment, error) {
+			enrichment := fetched[source.Name][name]
+			if enrichment == nil {
+				return nil, errNoResult
+			}
+			return enrichment, nil
+		}
+
+		var (
+			enrichment entity.Enrichment
+			err        error
+		)
+
+		enrichment.Age, err = resolve(c.age, func(source Source) (*entity.AgePrediction, error) {
+			e, err := lookup(source)
+			if err != nil {
+				return nil, err
+			}
+			return field(e, entity.FieldAge, e.Age)
+		}, combineAge)
+		if err != nil {
+			enrichment.SetError(entity.FieldAge, err)
+		}
+
+		enrichment.Gender, err = resolve(c.gender, func(source Source) (*entity.GenderPrediction, error) {
+			e, err := lookup(source)
+			if err != nil {
+				return nil, err
+			}
+			return field(e, entity.FieldGender, e.Gender)
+		}, combineGender)
+		if err != nil {
+			enrichment.SetError(entity.FieldGender, err)
+		}
+
+		enrichment.Nationality, err = resolve(c.nationality, func(source Source) (*entity.NationalityPrediction, error) {
+			e, err := lookup(source)
+			if err != nil {
+				return nil, err
+			}
+			return field(e, entity.FieldNationality, e.Nationality)
+		}, combineNationality)
+		if err != nil {
+			enrichment.SetError(entity.FieldNationality, err)
+		}
+
+		results[name] = enrichment.Without(skip...)
+	}
+
+	return results
+}
+
+func (c *Client) fetch(ctx context.Context, names []string, countryID string, skip []string) map[string]map[string]*entity.Enrichment {
+	fetched := make(map[string]map[string]*entity.Enrichment)
+
+	for _, source := range c.sources() {
+		pending := make([]string, 0, len(names))
+		for _, name := range names {
+			if c.needs(fetched, source.Name, name, skip) {
+				pending = append(pending, name)
+			}
+		}
+
+		if len(pending) > 0 {
+			fetched[source.Name] = source.Client.EnrichPeople(ctx, pending, countryID, skip...)
+		}
+	}
+
+	return fetched
+}
+
+func (c *Client) needs(fetched map[string]map[string]*entity.Enrichment, source, name string, skip []string) bool {
+	for _, attribute := range []struct {
+		field string
+		chain Chain
+		found func(*entity.Enrichment) bool
+	}{
+		{entity.FieldAge, c.age, func(e *entity.Enrichment) bool { return e.Age != nil }},
+		{entity.FieldGender, c.gender, func(e *entity.Enrichment) bool { return e.Gender != nil }},
+		{entity.FieldNationality, c.nationality, func(e *entity.Enrichment) bool { return e.Nationality != nil }},
+	} {
+		i := attribute.chain.index(source)
+		if i < 0 || slices.Contains(skip, attribute.field) {
+			continue
+		}
+		if attribute.chain.Strategy == StrategyEnsemble {
+			return true
+		}
+
+		satisfied := false
+		for _, earlier := range attribute.chain.Sources[:i] {
+			if e := fetched[earlier.Name][name]; e != nil && attribute.found(e) {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Client) sources() []Source {
+	var sources []Source
+	seen := make(map[string]struct{})
+
+	for _, chain := range []Chain{c.age, c.gender, c.nationality} {
+		for _, source := range chain.Sources {
+			if _, ok := seen[source.Name]; ok {
+				continue
+			}
+			seen[source.Name] = struct{}{}
+			sources = append(sources, source)
+		}
+	}
+
+	return sources
+}
+
+func field[T any](enrichment *entity.Enrichment, name string, prediction *T) (*T, error) {
+	if prediction != nil {
+		return prediction, nil
+	}
+	if err, ok := enrichment.Errors[name]; ok {
+		return nil, err
+	}
+
+	return nil, entity.ErrNoPrediction
+}
@@ -0,0 +1,103 @@
+package composite
+
+import (
+	"math"
+	"sort"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+const ageTolerance = 5
+
+var oppositeGender = map[string]string{
+	"male":   "female",
+	"female": "male",
+}
+
+func combineAge(votes []vote[entity.AgePrediction]) *entity.AgePrediction {
+	if len(votes) == 1 {
+		return votes[0].prediction
+	}
+
+	var total, sum float64
+	result := entity.AgePrediction{Source: entity.SourceEnsemble}
+
+	for _, v := range votes {
+		total += v.weight
+		sum += v.weight * float64(v.prediction.Age)
+		result.Count += v.prediction.Count
+	}
+	result.Age = int(math.Round(sum / total))
+
+	var agreeing float64
+	for _, v := range votes {
+		if math.Abs(float64(v.prediction.Age-result.Age)) <= ageTolerance {
+			agreeing += v.weight
+		}
+	}
+	result.Confidence = agreeing / total
+
+	return &result
+}
+
+func combineGender(votes []vote[entity.GenderPrediction]) *entity.GenderPrediction {
+	if len(votes) == 1 {
+		return votes[0].prediction
+	}
+
+	var total float64
+	scores := make(map[string]float64)
+	result := entity.GenderPrediction{Source: entity.SourceEnsemble}
+
+	for _, v := range votes {
+		total += v.weight
+		scores[v.prediction.Gender] += v.weight * v.prediction.Probability
+		if opposite, ok := oppositeGender[v.prediction.Gender]; ok {
+			scores[opposite] += v.weight * (1 - v.prediction.Probability)
+		}
+		result.Count += v.prediction.Count
+	}
+
+	for gender, score := range scores {
+		if result.Gender == "" || score > scores[result.Gender] || score == scores[result.Gender] && gender < result.Gender {
+			result.Gender = gender
+		}
+	}
+	result.Probability = scores[result.Gender] / total
+
+	return &result
+}
+
+func combineNationality(votes []vote[entity.NationalityPrediction]) *entity.NationalityPrediction {
+	if len(votes) == 1 {
+		return votes[0].prediction
+	}
+
+	var total float64
+	scores := make(map[string]float64)
+	result := entity.NationalityPrediction{Source: entity.SourceEnsemble}
+
+	for _, v := range votes {
+		total += v.weight
+		for _, country := range v.prediction.Countries {
+			scores[country.CountryID] += v.weight * country.Probability
+		}
+		result.Count += v.prediction.Count
+	}
+
+	result.Countries = make([]entity.CountryProbability, 0, len(scores))
+	for countryID, score := range scores {
+		result.Countries = append(result.Countries, entity.CountryProbability{
+			CountryID:   countryID,
+			Probability: score / total,
+		})
+	}
+	sort.Slice(result.Countries, func(i, j int) bool {
+		if result.Countries[i].Probability != result.Countries[j].Probability {
+			return result.Countries[i].Probability > result.Countries[j].Probability
+		}
+		return result.Countries[i].CountryID < result.Countries[j].CountryID
+	})
+
+	return &result
+}
@@ -0,0 +1,103 @@
+package dataset
+
+import (
+	"context"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+type Client struct {
+	dataset *Dataset
+}
+
+func NewClient(dataset *Dataset) *Client {
+	return &Client{
+		dataset: dataset,
+	}
+}
+
+func (c *Client) GetAge(ctx context.Context, name, countryID string) (*entity.AgePrediction, error) {
+	record, ok := c.dataset.Lookup(name)
+	if !ok || record.Age == nil {
+		return nil, entity.ErrNoPrediction
+	}
+
+	return &entity.AgePrediction{
+		Age:    *record.Age,
+		Count:  record.AgeCount,
+		Source: entity.SourceDataset,
+	}, nil
+}
+
+func (c *Client) GetGender(ctx context.Context, name, countryID string) (*entity.GenderPrediction, error) {
+	record, ok := c.dataset.Lookup(name)
+	if !ok || record.Gender == nil {
+		return nil, entity.ErrNoPrediction
+	}
+
+	return &entity.GenderPrediction{
+		Gender:      *record.Gender,
+		Probability: record.GenderProbability,
+		Count:       record.GenderCount,
+		Source:      entity.SourceDataset,
+	}, nil
+}
+
+func (c *Client) GetNationality(ctx context.Context, name string) (*entity.NationalityPrediction, error) {
+	record, ok := c.dataset.Lookup(name)
+	if !ok || len(record.Countries) == 0 {
+		return nil, entity.ErrNoPrediction
+	}
+
+	countries := make([]entity.CountryProbability, len(record.Countries))
+	copy(countries, record.Countries)
+
+	return &entity.NationalityPrediction{
+		Countries: countries,
+		Source:    entity.SourceDataset,
+	}, nil
+}
+
+func (c *Client) EnrichPerson(ctx context.Context, name, countryID string) (*entity.Enrichment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var (
+		enrichment entity.Enrichment
+		err        error
+	)
+
+	if enrichment.Age, err = c.GetAge(ctx, name, countryID); err != nil {
+		enrichment.SetError(entity.FieldAge, err)
+	}
+	if enrichment.Gender, err = c.GetGender(ctx, name, countryID); err != nil {
+		enrichment.SetError(entity.FieldGender, err)
+	}
+	if enrichment.Nationality, err = c.GetNationality(ctx, name); err != nil {
+		enrichment.SetError(entity.FieldNationality, err)
+	}
+
+	return &enrichment, nil
+}
+
+func (c *Client) EnrichPeople(ctx context.Context, names []string, countryID string, skip ...string) map[string]*entity.Enrichment {
+	results := make(map[string]*entity.Enrichment, len(names))
+
+	for _, name := range names {
+		if _, ok := results[name]; ok {
+			continue
+		}
+
+		enrichment, err := c.EnrichPerson(ctx, name, countryID)
+		if err != nil {
+			enrichment = &entity.Enrichment{}
+			enrichment.SetError(entity.FieldAge, err)
+			enrichment.SetError(entity.FieldGender, err)
+			enrichment.SetError(entity.FieldNationality, err)
+		}
+		results[name] = enrichment.Without(skip...)
+	}
+
+	return results
+}
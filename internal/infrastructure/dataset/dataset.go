@@ -0,0 +1,195 @@
+package dataset
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+type Record struct {
+	Name              string                      `json:"name"`
+	Age               *int                        `json:"age"`
+	AgeCount          int                         `json:"age_count"`
+	Gender            *string                     `json:"gender"`
+	GenderProbability float64                     `json:"gender_probability"`
+	GenderCount       int                         `json:"gender_count"`
+	Countries         []entity.CountryProbability `json:"countries"`
+}
+
+type Dataset struct {
+	records map[string]*Record
+}
+
+func Load(path string) (*Dataset, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset: %w", err)
+	}
+	defer file.Close()
+
+	var records []*Record
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		records, err = readCSV(file)
+	case ".json":
+		err = json.NewDecoder(file).Decode(&records)
+	default:
+		return nil, fmt.Errorf("unsupported dataset format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset %s: %w", path, err)
+	}
+
+	dataset := &Dataset{
+		records: make(map[string]*Record, len(records)),
+	}
+	for _, record := range records {
+		sort.SliceStable(record.Countries, func(i, j int) bool {
+			return record.Countries[i].Probability > record.Countries[j].Probability
+		})
+		dataset.records[normalizeName(record.Name)] = record
+	}
+
+	return dataset, nil
+}
+
+func (d *Dataset) Lookup(name string) (*Record, bool) {
+	record, ok := d.records[normalizeName(name)]
+	return record, ok
+}
+
+func (d *Dataset) Len() int {
+	return len(d.records)
+}
+
+func readCSV(r io.Reader) ([]*Record, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.TrimSpace(strings.ToLower(column))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("missing name column")
+	}
+
+	var records []*Record
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := parseRow(columns, row)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func parseRow(columns map[string]int, row []string) (*Record, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	record := &Record{
+		Name: field("name"),
+	}
+
+	if raw := field("age"); raw != "" {
+		age, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid age %q", raw)
+		}
+		record.Age = &age
+	}
+	if raw := field("gender"); raw != "" {
+		record.Gender = &raw
+	}
+
+	var err error
+	if record.AgeCount, err = parseInt(field("age_count")); err != nil {
+		return nil, fmt.Errorf("invalid age_count: %w", err)
+	}
+	if record.GenderCount, err = parseInt(field("gender_count")); err != nil {
+		return nil, fmt.Errorf("invalid gender_count: %w", err)
+	}
+	if record.GenderProbability, err = parseFloat(field("gender_probability")); err != nil {
+		return nil, fmt.Errorf("invalid gender_probability: %w", err)
+	}
+	if record.Countries, err = parseCountries(field("countries")); err != nil {
+		return nil, fmt.Errorf("invalid countries: %w", err)
+	}
+
+	return record, nil
+}
+
+func parseCountries(raw string) ([]entity.CountryProbability, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, "|")
+	countries := make([]entity.CountryProbability, 0, len(parts))
+	for _, part := range parts {
+		id, probability, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("expected COUNTRY:probability, got %q", part)
+		}
+
+		p, err := strconv.ParseFloat(strings.TrimSpace(probability), 64)
+		if err != nil {
+			return nil, err
+		}
+
+		countries = append(countries, entity.CountryProbability{
+			CountryID:   strings.ToUpper(strings.TrimSpace(id)),
+			Probability: p,
+		})
+	}
+
+	return countries, nil
+}
+
+func parseInt(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(raw)
+}
+
+func parseFloat(raw string) (float64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(raw, 64)
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
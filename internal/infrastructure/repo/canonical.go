@@ -0,0 +1,56 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+func (r *PostgresRepository) GetUncanonicalized(ctx context.Context, version, limit int) ([]*entity.Person, error) {
+	query := `
+		SELECT ` + personColumns + `
+		FROM persons
+		WHERE canonical_version < $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, version, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get persons without canonical names: %w", err)
+	}
+	defer rows.Close()
+
+	var persons []*entity.Person
+	for rows.Next() {
+		person, err := scanPerson(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan person: %w", err)
+		}
+		persons = append(persons, person)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating persons: %w", err)
+	}
+
+	return persons, nil
+}
+
+func (r *PostgresRepository) UpdateCanonical(ctx context.Context, person *entity.Person) error {
+	query := `
+		UPDATE persons
+		SET resolved_name = $1, canonical_name = $2, name_phonetic = $3, surname_phonetic = $4, patronymic_phonetic = $5,
+			canonical_version = $6
+		WHERE id = $7
+	`
+
+	_, err := r.db.ExecContext(ctx, query, person.ResolvedName, person.CanonicalName,
+		person.Phonetic.Name, person.Phonetic.Surname, person.Phonetic.Patronymic, person.CanonicalVersion, person.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update canonical names: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+const diminutiveColumns = `diminutive, canonical, created_at, updated_at`
+
+type PostgresDiminutiveRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresDiminutiveRepository(db *sql.DB) *PostgresDiminutiveRepository {
+	return &PostgresDiminutiveRepository{
+		db: db,
+	}
+}
+
+func scanDiminutive(row rowScanner) (*entity.Diminutive, error) {
+	var diminutive entity.Diminutive
+
+	err := row.Scan(
+		&diminutive.Diminutive,
+		&diminutive.Canonical,
+		&diminutive.CreatedAt,
+		&diminutive.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &diminutive, nil
+}
+
+func (r *PostgresDiminutiveRepository) List(ctx context.Context) ([]*entity.Diminutive, error) {
+	query := `
+		SELECT ` + diminutiveColumns + `
+		FROM name_diminutives
+		ORDER BY canonical, diminutive
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list diminutives: %w", err)
+	}
+	defer rows.Close()
+
+	var diminutives []*entity.Diminutive
+	for rows.Next() {
+		diminutive, err := scanDiminutive(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan diminutive: %w", err)
+		}
+		diminutives = append(diminutives, diminutive)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating diminutives: %w", err)
+	}
+
+	return diminutives, nil
+}
+
+func (r *PostgresDiminutiveRepository) Get(ctx context.Context, diminutive string) (*entity.Diminutive, error) {
+	query := `
+		SELECT ` + diminutiveColumns + `
+		FROM name_diminutives
+		WHERE diminutive = $1
+	`
+
+	result, err := scanDiminutive(r.db.QueryRowContext(ctx, query, diminutive))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entity.ErrDiminutiveNotFound
+		}
+		return nil, fmt.Errorf("failed to get diminutive: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *PostgresDiminutiveRepository) Create(ctx context.Context, diminutive *entity.Diminutive) error {
+	query := `
+		INSERT INTO name_diminutives (diminutive, canonical, created_at, updated_at)
+		VALUES ($1, $2, $3, $3)
+		ON CONFLICT (diminutive) DO NOTHING
+	`
+
+	now := time.Now().Format(time.RFC3339)
+
+	result, err := r.db.ExecContext(ctx, query, diminutive.Diminutive, diminutive.Canonical, now)
+	if err != nil {
+		return fmt.Errorf("failed to create diminutive: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrDiminutiveExists
+	}
+
+	diminutive.CreatedAt = now
+	diminutive.UpdatedAt = now
+	return nil
+}
+
+func (r *PostgresDiminutiveRepository) Update(ctx context.Context, diminutive *entity.Diminutive) error {
+	query := `
+		UPDATE name_diminutives
+		SET canonical = $1, updated_at = NOW()
+		WHERE diminutive = $2
+		RETURNING ` + diminutiveColumns
+
+	result, err := scanDiminutive(r.db.QueryRowContext(ctx, query, diminutive.Canonical, diminutive.Diminutive))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entity.ErrDiminutiveNotFound
+		}
+		return fmt.Errorf("failed to update diminutive: %w", err)
+	}
+
+	*diminutive = *result
+	return nil
+}
+
+func (r *PostgresDiminutiveRepository) Delete(ctx context.Context, diminutive string) error {
+	query := `
+		DELETE FROM name_diminutives
+		WHERE diminutive = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, diminutive)
+	if err != nil {
+		return fmt.Errorf("failed to delete diminutive: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrDiminutiveNotFound
+	}
+
+	return nil
+}
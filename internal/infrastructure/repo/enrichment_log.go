@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+const enrichmentLogColumns = `id, person_id, provider, request_url, status_code, latency_ms, response_body, error, created_at`
+
+type PostgresEnrichmentLogRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresEnrichmentLogRepository(db *sql.DB) *PostgresEnrichmentLogRepository {
+	return &PostgresEnrichmentLogRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresEnrichmentLogRepository) Save(ctx context.Context, entries []entity.EnrichmentLogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(entries))
+	args := make([]interface{}, 0, len(entries)*8)
+	for _, entry := range entries {
+		n := len(args)
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8))
+		args = append(args,
+			entry.PersonID,
+			entry.Provider,
+			entry.RequestURL,
+			entry.StatusCode,
+			entry.LatencyMs,
+			entry.ResponseBody,
+			entry.Error,
+			entry.CreatedAt,
+		)
+	}
+
+	query := `
+		INSERT INTO enrichment_log (person_id, provider, request_url, status_code, latency_ms, response_body, error, created_at)
+		VALUES ` + strings.Join(values, ", ")
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save enrichment log: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresEnrichmentLogRepository) ListByPersonID(ctx context.Context, personID int64, limit int) ([]*entity.EnrichmentLogEntry, error) {
+	query := `
+		SELECT ` + enrichmentLogColumns + `
+		FROM enrichment_log
+		WHERE person_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, personID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrichment log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*entity.EnrichmentLogEntry
+	for rows.Next() {
+		var entry entity.EnrichmentLogEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.PersonID,
+			&entry.Provider,
+			&entry.RequestURL,
+			&entry.StatusCode,
+			&entry.LatencyMs,
+			&entry.ResponseBody,
+			&entry.Error,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan enrichment log entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating enrichment log: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,206 @@
+package repo
+
+import (
+	"fmt"
+	"strings"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+const countryClause = "countries @> jsonb_build_array(jsonb_build_object('country_id', %s::text))"
+
+var textColumns = []string{"name", "resolved_name", "surname", "patronymic"}
+
+var filterColumns = map[entity.FilterField]string{
+	entity.FilterID:                  "id",
+	entity.FilterName:                "name",
+	entity.FilterResolvedName:        "resolved_name",
+	entity.FilterCanonicalName:       "canonical_name",
+	entity.FilterSurname:             "surname",
+	entity.FilterPatronymic:          "patronymic",
+	entity.FilterAge:                 "age",
+	entity.FilterAgeCount:            "age_count",
+	entity.FilterAgeSource:           "age_source",
+	entity.FilterAgeConfidence:       "age_confidence",
+	entity.FilterGender:              "gender",
+	entity.FilterGenderProbability:   "gender_probability",
+	entity.FilterGenderCount:         "gender_count",
+	entity.FilterGenderSource:        "gender_source",
+	entity.FilterNationality:         "nationality",
+	entity.FilterNationalitySource:   "nationality_source",
+	entity.FilterCountryHint:         "country_hint",
+	entity.FilterLocalizationCountry: "localization_country",
+	entity.FilterEnrichmentStatus:    "enrichment_status",
+	entity.FilterNeedsReview:         "needs_review",
+	entity.FilterEnrichedAt:          "enriched_at",
+	entity.FilterCreatedAt:           "created_at",
+	entity.FilterUpdatedAt:           "updated_at",
+}
+
+type queryArgs struct {
+	values []interface{}
+}
+
+func (a *queryArgs) add(value interface{}) string {
+	a.values = append(a.values, value)
+	return fmt.Sprintf("$%d", len(a.values))
+}
+
+var comparisons = map[entity.FilterOp]string{
+	entity.OpGt:  " > ",
+	entity.OpGte: " >= ",
+	entity.OpLt:  " < ",
+	entity.OpLte: " <= ",
+}
+
+func compileFilter(filter *entity.Filter, args *queryArgs) (string, error) {
+	if err := filter.Validate(); err != nil {
+		return "", err
+	}
+
+	return compileWhere(filter.Node(), args)
+}
+
+func compileWhere(node *entity.FilterNode, args *queryArgs) (string, error) {
+	if node == nil {
+		return "", nil
+	}
+
+	clause, err := compileNode(node, args)
+	if err != nil {
+		return "", err
+	}
+
+	return " WHERE " + clause, nil
+}
+
+func compileNode(node *entity.FilterNode, args *queryArgs) (string, error) {
+	switch {
+	case node.Condition != nil:
+		return compileCondition(*node.Condition, args)
+	case node.Not != nil:
+		clause, err := compileNode(node.Not, args)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + clause + ")", nil
+	}
+
+	operator, children := " AND ", node.And
+	if node.Or != nil {
+		operator, children = " OR ", node.Or
+	}
+
+	clauses := make([]string, 0, len(children))
+	for _, child := range children {
+		clause, err := compileNode(child, args)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, clause)
+	}
+
+	return "(" + strings.Join(clauses, operator) + ")", nil
+}
+
+func compileSort(sort []entity.SortOrder) (string, error) {
+	orders := make([]string, 0, len(sort)+1)
+	for _, order := range sort {
+		column, ok := filterColumns[order.Field]
+		if !ok {
+			return "", fmt.Errorf("%w: cannot sort by %q", entity.ErrInvalidFilter, order.Field)
+		}
+		if order.Desc {
+			orders = append(orders, column+" DESC NULLS LAST")
+		} else {
+			orders = append(orders, column+" ASC NULLS LAST")
+		}
+	}
+	orders = append(orders, "id")
+
+	return " ORDER BY " + strings.Join(orders, ", "), nil
+}
+
+func compileCondition(condition entity.Condition, args *queryArgs) (string, error) {
+	switch condition.Field {
+	case entity.FilterCountry:
+		return compileCountry(condition, args), nil
+	case entity.FilterText:
+		return compileText(condition, args), nil
+	}
+
+	column, ok := filterColumns[condition.Field]
+	if !ok {
+		return "", fmt.Errorf("%w: unsupported field %q", entity.ErrInvalidFilter, condition.Field)
+	}
+
+	switch condition.Op {
+	case entity.OpEq:
+		return column + " = " + args.add(condition.Value), nil
+	case entity.OpNe:
+		return column + " IS DISTINCT FROM " + args.add(condition.Value), nil
+	case entity.OpIn:
+		placeholders := make([]string, len(condition.Values))
+		for i, value := range condition.Values {
+			placeholders[i] = args.add(value)
+		}
+		return column + " IN (" + strings.Join(placeholders, ", ") + ")", nil
+	case entity.OpGt, entity.OpGte, entity.OpLt, entity.OpLte:
+		return column + comparisons[condition.Op] + args.add(condition.Value), nil
+	case entity.OpRange:
+		var bounds []string
+		if condition.From != nil {
+			bounds = append(bounds, column+" >= "+args.add(condition.From))
+		}
+		if condition.To != nil {
+			bounds = append(bounds, column+" <= "+args.add(condition.To))
+		}
+		return "(" + strings.Join(bounds, " AND ") + ")", nil
+	case entity.OpPrefix:
+		return column + " ILIKE " + args.add(escapeLike(condition.Value.(string))+"%"), nil
+	case entity.OpContains:
+		return column + " ILIKE " + args.add("%"+escapeLike(condition.Value.(string))+"%"), nil
+	case entity.OpIsNull:
+		if entity.FilterFields[condition.Field].Kind == entity.KindString {
+			return "COALESCE(" + column + ", '') = ''", nil
+		}
+		return column + " IS NULL", nil
+	case entity.OpNotNull:
+		if entity.FilterFields[condition.Field].Kind == entity.KindString {
+			return "COALESCE(" + column + ", '') <> ''", nil
+		}
+		return column + " IS NOT NULL", nil
+	default:
+		return "", fmt.Errorf("%w: unsupported operator %q", entity.ErrInvalidFilter, condition.Op)
+	}
+}
+
+func compileCountry(condition entity.Condition, args *queryArgs) string {
+	switch condition.Op {
+	case entity.OpNe:
+		return "NOT " + fmt.Sprintf(countryClause, args.add(condition.Value))
+	case entity.OpIn:
+		clauses := make([]string, len(condition.Values))
+		for i, value := range condition.Values {
+			clauses[i] = fmt.Sprintf(countryClause, args.add(value))
+		}
+		return "(" + strings.Join(clauses, " OR ") + ")"
+	default:
+		return fmt.Sprintf(countryClause, args.add(condition.Value))
+	}
+}
+
+func compileText(condition entity.Condition, args *queryArgs) string {
+	placeholder := args.add("%" + escapeLike(condition.Value.(string)) + "%")
+
+	clauses := make([]string, len(textColumns))
+	for i, column := range textColumns {
+		clauses[i] = column + " ILIKE " + placeholder
+	}
+
+	return "(" + strings.Join(clauses, " OR ") + ")"
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
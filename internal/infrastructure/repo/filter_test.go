@@ -0,0 +1,146 @@
+package repo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+func TestCompileFilter(t *testing.T) {
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filter   *entity.Filter
+		wantSQL  string
+		wantArgs []interface{}
+		wantErr  error
+	}{
+		{
+			name:    "no filter",
+			filter:  nil,
+			wantSQL: "",
+		},
+		{
+			name:     "equality",
+			filter:   entity.NewFilter().Eq(entity.FilterGender, "female"),
+			wantSQL:  " WHERE (gender = $1)",
+			wantArgs: []interface{}{"female"},
+		},
+		{
+			name:     "range and in",
+			filter:   entity.NewFilter().Range(entity.FilterAge, 20, 40).In(entity.FilterNationality, "RU", "UA"),
+			wantSQL:  " WHERE ((age >= $1 AND age <= $2) AND nationality IN ($3, $4))",
+			wantArgs: []interface{}{20, 40, "RU", "UA"},
+		},
+		{
+			name:     "open range",
+			filter:   entity.NewFilter().Range(entity.FilterCreatedAt, createdAfter, nil),
+			wantSQL:  " WHERE ((created_at >= $1))",
+			wantArgs: []interface{}{createdAfter},
+		},
+		{
+			name:     "not equal keeps nulls",
+			filter:   entity.NewFilter().Ne(entity.FilterGender, "male"),
+			wantSQL:  " WHERE (gender IS DISTINCT FROM $1)",
+			wantArgs: []interface{}{"male"},
+		},
+		{
+			name:     "any of countries",
+			filter:   entity.NewFilter().In(entity.FilterCountry, "RU", "BY"),
+			wantSQL:  " WHERE ((" + countryMatch("$1") + " OR " + countryMatch("$2") + "))",
+			wantArgs: []interface{}{"RU", "BY"},
+		},
+		{
+			name:     "text search escapes wildcards",
+			filter:   entity.NewFilter().Contains(entity.FilterText, `50%_off\`),
+			wantSQL:  " WHERE ((name ILIKE $1 OR resolved_name ILIKE $1 OR surname ILIKE $1 OR patronymic ILIKE $1))",
+			wantArgs: []interface{}{`%50\%\_off\\%`},
+		},
+		{
+			name:     "prefix",
+			filter:   entity.NewFilter().Prefix(entity.FilterSurname, "Ив"),
+			wantSQL:  " WHERE (surname ILIKE $1)",
+			wantArgs: []interface{}{"Ив%"},
+		},
+		{
+			name:    "empty patronymic is null",
+			filter:  entity.NewFilter().IsNull(entity.FilterPatronymic).NotNull(entity.FilterAge),
+			wantSQL: " WHERE (COALESCE(patronymic, '') = '' AND age IS NOT NULL)",
+		},
+		{
+			name:    "invalid filter",
+			filter:  entity.NewFilter().Eq(entity.FilterAge, "forty"),
+			wantErr: entity.ErrInvalidFilter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &queryArgs{}
+			sql, err := compileFilter(tt.filter, args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("compileFilter() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if sql != tt.wantSQL {
+				t.Errorf("compileFilter() sql = %q, want %q", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args.values, tt.wantArgs) {
+				t.Errorf("compileFilter() args = %#v, want %#v", args.values, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func countryMatch(placeholder string) string {
+	return "countries @> jsonb_build_array(jsonb_build_object('country_id', " + placeholder + "::text))"
+}
+
+func TestCompileSearch(t *testing.T) {
+	condition := func(field entity.FilterField, op entity.FilterOp, value any) *entity.FilterNode {
+		return &entity.FilterNode{Condition: &entity.Condition{Field: field, Op: op, Value: value}}
+	}
+
+	query := &entity.SearchQuery{
+		Where: &entity.FilterNode{Or: []*entity.FilterNode{
+			{And: []*entity.FilterNode{
+				{Condition: &entity.Condition{Field: entity.FilterNationality, Op: entity.OpIn, Values: []any{"RU", "BY"}}},
+				condition(entity.FilterAge, entity.OpGt, 40),
+			}},
+			{Not: condition(entity.FilterGender, entity.OpEq, "male")},
+		}},
+		Sort: []entity.SortOrder{{Field: entity.FilterAge, Desc: true}},
+	}
+	if err := query.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	args := &queryArgs{}
+	where, err := compileWhere(query.Where, args)
+	if err != nil {
+		t.Fatalf("compileWhere() error = %v", err)
+	}
+
+	wantWhere := " WHERE ((nationality IN ($1, $2) AND age > $3) OR NOT (gender = $4))"
+	if where != wantWhere {
+		t.Errorf("compileWhere() = %q, want %q", where, wantWhere)
+	}
+	if wantArgs := []interface{}{"RU", "BY", 40, "male"}; !reflect.DeepEqual(args.values, wantArgs) {
+		t.Errorf("compileWhere() args = %#v, want %#v", args.values, wantArgs)
+	}
+
+	order, err := compileSort(query.Sort)
+	if err != nil {
+		t.Fatalf("compileSort() error = %v", err)
+	}
+	if want := " ORDER BY age DESC NULLS LAST, id"; order != want {
+		t.Errorf("compileSort() = %q, want %q", order, want)
+	}
+}
@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+const phoneticScore = 0.8
+
+func compileFuzzy(query *entity.FuzzyQuery, args *queryArgs) (string, string) {
+	matches := make([]string, len(query.Terms))
+	scores := make([]string, len(query.Terms))
+
+	for i, term := range query.Terms {
+		text := args.add(term.Text)
+		phonetic := "NULLIF(" + args.add(term.Phonetic) + ", '')"
+
+		matches[i] = "(name % " + text + " OR surname % " + text + " OR patronymic % " + text +
+			" OR name_phonetic = " + phonetic + " OR surname_phonetic = " + phonetic + " OR patronymic_phonetic = " + phonetic + ")"
+		scores[i] = "GREATEST(similarity(name, " + text + "), similarity(surname, " + text + "), similarity(patronymic, " + text + "), " +
+			"CASE WHEN " + phonetic + " IN (name_phonetic, surname_phonetic, patronymic_phonetic) THEN " + fmt.Sprint(phoneticScore) + " END)"
+	}
+
+	return strings.Join(matches, " AND "), "(" + strings.Join(scores, " + ") + ") / " + fmt.Sprint(len(query.Terms))
+}
+
+func (r *PostgresRepository) FuzzySearch(ctx context.Context, query *entity.FuzzyQuery, filter *entity.Filter, page, limit int) ([]*entity.ScoredPerson, int, error) {
+	if len(query.Terms) == 0 {
+		return nil, 0, fmt.Errorf("failed to build fuzzy search: %w: no search terms", entity.ErrInvalidFilter)
+	}
+
+	args := &queryArgs{}
+	whereClause, err := compileFilter(filter, args)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build filter: %w", err)
+	}
+
+	match, score := compileFuzzy(query, args)
+	if whereClause == "" {
+		whereClause = " WHERE " + match
+	} else {
+		whereClause += " AND " + match
+	}
+
+	matched := `
+		FROM (
+			SELECT ` + personColumns + `, ` + score + ` AS score
+			FROM persons` + whereClause + `
+		) matched
+		WHERE score >= ` + args.add(query.MinScore)
+
+	var totalCount int
+	err = r.db.QueryRowContext(ctx, "SELECT COUNT(*)"+matched, args.values...).Scan(&totalCount)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count persons: %w", err)
+	}
+
+	offset := (page - 1) * limit
+	paginationClause := " ORDER BY score DESC, id LIMIT " + args.add(limit) + " OFFSET " + args.add(offset)
+
+	rows, err := r.db.QueryContext(ctx, "SELECT "+personColumns+", score"+matched+paginationClause, args.values...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search persons: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*entity.ScoredPerson
+	for rows.Next() {
+		var score float64
+		person, err := scanPerson(rows, &score)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan person: %w", err)
+		}
+		results = append(results, &entity.ScoredPerson{Person: person, Score: score})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating persons: %w", err)
+	}
+
+	return results, totalCount, nil
+}
@@ -0,0 +1,150 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+const jobColumns = `id, person_id, status, attempts, last_error, run_at, created_at, updated_at`
+
+type PostgresJobRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresJobRepository(db *sql.DB) *PostgresJobRepository {
+	return &PostgresJobRepository{
+		db: db,
+	}
+}
+
+func scanJob(row rowScanner) (*entity.EnrichmentJob, error) {
+	var job entity.EnrichmentJob
+
+	err := row.Scan(
+		&job.ID,
+		&job.PersonID,
+		&job.Status,
+		&job.Attempts,
+		&job.LastError,
+		&job.RunAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *PostgresJobRepository) Enqueue(ctx context.Context, personID int64) (*entity.EnrichmentJob, error) {
+	query := `
+		INSERT INTO enrichment_jobs (person_id)
+		VALUES ($1)
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, query, personID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue enrichment job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r *PostgresJobRepository) Claim(ctx context.Context, lease time.Duration, maxAttempts int) (*entity.EnrichmentJob, error) {
+	query := `
+		WITH next AS (
+			SELECT id AS job_id, status = 'running' AND attempts >= $2 AS exhausted
+			FROM enrichment_jobs
+			WHERE (status = 'pending' AND run_at <= NOW())
+			   OR (status = 'running' AND locked_until < NOW())
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE enrichment_jobs
+		SET status = CASE WHEN next.exhausted THEN 'dead' ELSE 'running' END,
+		    attempts = CASE WHEN next.exhausted THEN attempts ELSE attempts + 1 END,
+		    last_error = CASE WHEN next.exhausted THEN 'lease expired' ELSE last_error END,
+		    locked_until = CASE WHEN next.exhausted THEN NULL ELSE $1::timestamptz END,
+		    updated_at = NOW()
+		FROM next
+		WHERE id = next.job_id
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, query, time.Now().Add(lease), maxAttempts))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim enrichment job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r *PostgresJobRepository) Complete(ctx context.Context, id int64) error {
+	query := `
+		UPDATE enrichment_jobs
+		SET status = 'done', last_error = '', locked_until = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to complete enrichment job: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresJobRepository) Retry(ctx context.Context, id int64, lastError string, runAt time.Time) error {
+	query := `
+		UPDATE enrichment_jobs
+		SET status = 'pending', last_error = $1, run_at = $2, locked_until = NULL, updated_at = NOW()
+		WHERE id = $3
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, lastError, runAt, id); err != nil {
+		return fmt.Errorf("failed to reschedule enrichment job: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresJobRepository) Bury(ctx context.Context, id int64, lastError string) error {
+	query := `
+		UPDATE enrichment_jobs
+		SET status = 'dead', last_error = $1, locked_until = NULL, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, lastError, id); err != nil {
+		return fmt.Errorf("failed to bury enrichment job: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresJobRepository) GetLatestByPersonID(ctx context.Context, personID int64) (*entity.EnrichmentJob, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM enrichment_jobs
+		WHERE person_id = $1
+		ORDER BY id DESC
+		LIMIT 1
+	`
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, query, personID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("enrichment job not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get enrichment job: %w", err)
+	}
+
+	return job, nil
+}
@@ -1,13 +1,17 @@
 package repo
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"Name_IQ_Finder/internal/entity"
 )
 
+const personColumns = `id, name, resolved_name, canonical_name, surname, patronymic, name_phonetic, surname_phonetic, patronymic_phonetic, canonical_version, age, age_count, age_source, age_confidence, gender, gender_probability, gender_count, gender_source, nationality, countries, name_countries, surname_countries, nationality_source, country_hint, localization_country, enrichment_status, needs_review, review, enriched_at, created_at, updated_at`
+
 type PostgresRepository struct {
 	db *sql.DB
 }
@@ -18,26 +22,201 @@ func NewPostgresRepository(db *sql.DB) *PostgresRepository {
 	}
 }
 
-func (r *PostgresRepository) Create(person *entity.Person) (int64, error) {
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPerson(row rowScanner, extra ...interface{}) (*entity.Person, error) {
+	var (
+		person             entity.Person
+		namePhonetic       sql.NullString
+		surnamePhonetic    sql.NullString
+		patronymicPhonetic sql.NullString
+		countries          []byte
+		nameCountries      []byte
+		surnameCountries   []byte
+		review             []byte
+	)
+
+	dests := []interface{}{
+		&person.ID,
+		&person.Name,
+		&person.ResolvedName,
+		&person.CanonicalName,
+		&person.Surname,
+		&person.Patronymic,
+		&namePhonetic,
+		&surnamePhonetic,
+		&patronymicPhonetic,
+		&person.CanonicalVersion,
+		&person.Age,
+		&person.AgeCount,
+		&person.AgeSource,
+		&person.AgeConfidence,
+		&person.Gender,
+		&person.GenderProbability,
+		&person.GenderCount,
+		&person.GenderSource,
+		&person.Nationality,
+		&countries,
+		&nameCountries,
+		&surnameCountries,
+		&person.NationalitySource,
+		&person.CountryHint,
+		&person.LocalizationCountry,
+		&person.EnrichmentStatus,
+		&person.NeedsReview,
+		&review,
+		&person.EnrichedAt,
+		&person.CreatedAt,
+		&person.UpdatedAt,
+	}
+	if err := row.Scan(append(dests, extra...)...); err != nil {
+		return nil, err
+	}
+
+	person.Phonetic = entity.PhoneticKeys{
+		Name:       namePhonetic.String,
+		Surname:    surnamePhonetic.String,
+		Patronymic: patronymicPhonetic.String,
+	}
+
+	if err := json.Unmarshal(countries, &person.Countries); err != nil {
+		return nil, fmt.Errorf("failed to decode countries: %w", err)
+	}
+
+	if nameCountries != nil {
+		if err := json.Unmarshal(nameCountries, &person.NameCountries); err != nil {
+			return nil, fmt.Errorf("failed to decode name countries: %w", err)
+		}
+	}
+
+	if surnameCountries != nil {
+		if err := json.Unmarshal(surnameCountries, &person.SurnameCountries); err != nil {
+			return nil, fmt.Errorf("failed to decode surname countries: %w", err)
+		}
+	}
+
+	if review != nil {
+		if err := json.Unmarshal(review, &person.Review); err != nil {
+			return nil, fmt.Errorf("failed to decode review: %w", err)
+		}
+	}
+
+	return &person, nil
+}
+
+func encodeCountries(countries []entity.CountryProbability) (string, error) {
+	if countries == nil {
+		countries = []entity.CountryProbability{}
+	}
+
+	data, err := json.Marshal(countries)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode countries: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func encodeComponent(countries []entity.CountryProbability) (*string, error) {
+	if countries == nil {
+		return nil, nil
+	}
+
+	encoded, err := encodeCountries(countries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encoded, nil
+}
+
+func encodeReview(review *entity.PendingReview) (*string, error) {
+	if review == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(review)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode review: %w", err)
+	}
+
+	encoded := string(data)
+	return &encoded, nil
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, person *entity.Person) (int64, error) {
 	query := `
-		INSERT INTO persons (name, surname, patronymic, age, gender, nationality, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO persons (name, resolved_name, canonical_name, surname, patronymic, name_phonetic, surname_phonetic, patronymic_phonetic,
+			canonical_version, age, age_count, age_source, age_confidence, gender, gender_probability, gender_count, gender_source,
+			nationality, countries, name_countries, surname_countries, nationality_source, country_hint, localization_country,
+			enrichment_status, needs_review, review, enriched_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25,
+			$26, $27, $28, $29, $30)
 		RETURNING id
 	`
 
+	countries, err := encodeCountries(person.Countries)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create person: %w", err)
+	}
+
+	nameCountries, err := encodeComponent(person.NameCountries)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create person: %w", err)
+	}
+
+	surnameCountries, err := encodeComponent(person.SurnameCountries)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create person: %w", err)
+	}
+
+	review, err := encodeReview(person.Review)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create person: %w", err)
+	}
+
+	if person.EnrichmentStatus == "" {
+		person.EnrichmentStatus = entity.EnrichmentEnriched
+	}
+
 	now := time.Now().Format(time.RFC3339)
 	person.CreatedAt = now
 	person.UpdatedAt = now
 
 	var id int64
-	err := r.db.QueryRow(
+	err = r.db.QueryRowContext(
+		ctx,
 		query,
 		person.Name,
+		person.ResolvedName,
+		person.CanonicalName,
 		person.Surname,
 		person.Patronymic,
+		person.Phonetic.Name,
+		person.Phonetic.Surname,
+		person.Phonetic.Patronymic,
+		person.CanonicalVersion,
 		person.Age,
+		person.AgeCount,
+		person.AgeSource,
+		person.AgeConfidence,
 		person.Gender,
+		person.GenderProbability,
+		person.GenderCount,
+		person.GenderSource,
 		person.Nationality,
+		countries,
+		nameCountries,
+		surnameCountries,
+		person.NationalitySource,
+		person.CountryHint,
+		person.LocalizationCountry,
+		person.EnrichmentStatus,
+		person.NeedsReview,
+		review,
+		person.EnrichedAt,
 		person.CreatedAt,
 		person.UpdatedAt,
 	).Scan(&id)
@@ -49,26 +228,14 @@ func (r *PostgresRepository) Create(person *entity.Person) (int64, error) {
 	return id, nil
 }
 
-func (r *PostgresRepository) GetByID(id int64) (*entity.Person, error) {
+func (r *PostgresRepository) GetByID(ctx context.Context, id int64) (*entity.Person, error) {
 	query := `
-		SELECT id, name, surname, patronymic, age, gender, nationality, created_at, updated_at
+		SELECT ` + personColumns + `
 		FROM persons
 		WHERE id = $1
 	`
 
-	var person entity.Person
-	err := r.db.QueryRow(query, id).Scan(
-		&person.ID,
-		&person.Name,
-		&person.Surname,
-		&person.Patronymic,
-		&person.Age,
-		&person.Gender,
-		&person.Nationality,
-		&person.CreatedAt,
-		&person.UpdatedAt,
-	)
-
+	person, err := scanPerson(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("person not found: %w", err)
@@ -76,12 +243,41 @@ func (r *PostgresRepository) GetByID(id int64) (*entity.Person, error) {
 		return nil, fmt.Errorf("failed to get person: %w", err)
 	}
 
-	return &person, nil
+	return person, nil
+}
+
+func (r *PostgresRepository) GetAll(ctx context.Context, filter *entity.Filter, page, limit int) ([]*entity.Person, int, error) {
+	args := &queryArgs{}
+	whereClause, err := compileFilter(filter, args)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build filter: %w", err)
+	}
+
+	return r.list(ctx, whereClause, " ORDER BY id", args, page, limit)
 }
 
-func (r *PostgresRepository) GetAll(filter map[string]interface{}, page, limit int) ([]*entity.Person, int, error) {
+func (r *PostgresRepository) Search(ctx context.Context, search *entity.SearchQuery) ([]*entity.Person, int, error) {
+	if err := search.Validate(); err != nil {
+		return nil, 0, fmt.Errorf("failed to build search: %w", err)
+	}
+
+	args := &queryArgs{}
+	whereClause, err := compileWhere(search.Where, args)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build search: %w", err)
+	}
+
+	orderClause, err := compileSort(search.Sort)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build search: %w", err)
+	}
+
+	return r.list(ctx, whereClause, orderClause, args, search.Page, search.Limit)
+}
+
+func (r *PostgresRepository) list(ctx context.Context, whereClause, orderClause string, args *queryArgs, page, limit int) ([]*entity.Person, int, error) {
 	query := `
-		SELECT id, name, surname, patronymic, age, gender, nationality, created_at, updated_at
+		SELECT ` + personColumns + `
 		FROM persons
 	`
 
@@ -90,33 +286,16 @@ func (r *PostgresRepository) GetAll(filter map[string]interface{}, page, limit i
 		FROM persons
 	`
 
-	whereClause := ""
-	args := []interface{}{}
-	argIndex := 1
-
-	if len(filter) > 0 {
-		whereClause = " WHERE "
-		for key, value := range filter {
-			if argIndex > 1 {
-				whereClause += " AND "
-			}
-			whereClause += fmt.Sprintf("%s = $%d", key, argIndex)
-			args = append(args, value)
-			argIndex++
-		}
-	}
-
-	offset := (page - 1) * limit
-	paginationClause := fmt.Sprintf(" ORDER BY id LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
-	args = append(args, limit, offset)
-
 	var totalCount int
-	err := r.db.QueryRow(countQuery+whereClause, args[:len(args)-2]...).Scan(&totalCount)
+	err := r.db.QueryRowContext(ctx, countQuery+whereClause, args.values...).Scan(&totalCount)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count persons: %w", err)
 	}
 
-	rows, err := r.db.Query(query+whereClause+paginationClause, args...)
+	offset := (page - 1) * limit
+	paginationClause := orderClause + " LIMIT " + args.add(limit) + " OFFSET " + args.add(offset)
+
+	rows, err := r.db.QueryContext(ctx, query+whereClause+paginationClause, args.values...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get persons: %w", err)
 	}
@@ -124,22 +303,11 @@ func (r *PostgresRepository) GetAll(filter map[string]interface{}, page, limit i
 
 	var persons []*entity.Person
 	for rows.Next() {
-		var person entity.Person
-		err := rows.Scan(
-			&person.ID,
-			&person.Name,
-			&person.Surname,
-			&person.Patronymic,
-			&person.Age,
-			&person.Gender,
-			&person.Nationality,
-			&person.CreatedAt,
-			&person.UpdatedAt,
-		)
+		person, err := scanPerson(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan person: %w", err)
 		}
-		persons = append(persons, &person)
+		persons = append(persons, person)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -149,23 +317,109 @@ func (r *PostgresRepository) GetAll(filter map[string]interface{}, page, limit i
 	return persons, totalCount, nil
 }
 
-func (r *PostgresRepository) Update(person *entity.Person) error {
+func (r *PostgresRepository) GetStale(ctx context.Context, enrichedBefore time.Time, limit int) ([]*entity.Person, error) {
 	query := `
 		UPDATE persons
-		SET name = $1, surname = $2, patronymic = $3, age = $4, gender = $5, nationality = $6, updated_at = $7
-		WHERE id = $8
+		SET refresh_attempted_at = NOW()
+		WHERE id IN (
+			SELECT id
+			FROM persons
+			WHERE enrichment_status <> 'pending'
+			  AND (enriched_at IS NULL OR enriched_at < $1)
+			  AND (refresh_attempted_at IS NULL OR refresh_attempted_at < $1)
+			ORDER BY refresh_attempted_at NULLS FIRST, enriched_at NULLS FIRST, id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + personColumns
+
+	rows, err := r.db.QueryContext(ctx, query, enrichedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stale persons: %w", err)
+	}
+	defer rows.Close()
+
+	var persons []*entity.Person
+	for rows.Next() {
+		person, err := scanPerson(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan person: %w", err)
+		}
+		persons = append(persons, person)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating persons: %w", err)
+	}
+
+	return persons, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, person *entity.Person) error {
+	query := `
+		UPDATE persons
+		SET name = $1, resolved_name = $2, canonical_name = $3, surname = $4, patronymic = $5, name_phonetic = $6,
+			surname_phonetic = $7, patronymic_phonetic = $8, canonical_version = $9, age = $10, age_count = $11, age_source = $12,
+			age_confidence = $13, gender = $14, gender_probability = $15, gender_count = $16, gender_source = $17, nationality = $18,
+			countries = $19, name_countries = $20, surname_countries = $21, nationality_source = $22, country_hint = $23,
+			localization_country = $24, enrichment_status = $25, needs_review = $26, review = $27, enriched_at = $28,
+			updated_at = $29
+		WHERE id = $30
 	`
 
+	countries, err := encodeCountries(person.Countries)
+	if err != nil {
+		return fmt.Errorf("failed to update person: %w", err)
+	}
+
+	nameCountries, err := encodeComponent(person.NameCountries)
+	if err != nil {
+		return fmt.Errorf("failed to update person: %w", err)
+	}
+
+	surnameCountries, err := encodeComponent(person.SurnameCountries)
+	if err != nil {
+		return fmt.Errorf("failed to update person: %w", err)
+	}
+
+	review, err := encodeReview(person.Review)
+	if err != nil {
+		return fmt.Errorf("failed to update person: %w", err)
+	}
+
 	person.UpdatedAt = time.Now().Format(time.RFC3339)
 
-	result, err := r.db.Exec(
+	result, err := r.db.ExecContext(
+		ctx,
 		query,
 		person.Name,
+		person.ResolvedName,
+		person.CanonicalName,
 		person.Surname,
 		person.Patronymic,
+		person.Phonetic.Name,
+		person.Phonetic.Surname,
+		person.Phonetic.Patronymic,
+		person.CanonicalVersion,
 		person.Age,
+		person.AgeCount,
+		person.AgeSource,
+		person.AgeConfidence,
 		person.Gender,
+		person.GenderProbability,
+		person.GenderCount,
+		person.GenderSource,
 		person.Nationality,
+		countries,
+		nameCountries,
+		surnameCountries,
+		person.NationalitySource,
+		person.CountryHint,
+		person.LocalizationCountry,
+		person.EnrichmentStatus,
+		person.NeedsReview,
+		review,
+		person.EnrichedAt,
 		person.UpdatedAt,
 		person.ID,
 	)
@@ -185,13 +439,13 @@ func (r *PostgresRepository) Update(person *entity.Person) error {
 	return nil
 }
 
-func (r *PostgresRepository) Delete(id int64) error {
+func (r *PostgresRepository) Delete(ctx context.Context, id int64) error {
 	query := `
 		DELETE FROM persons
 		WHERE id = $1
 	`
 
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete person: %w", err)
 	}
@@ -0,0 +1,144 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+const quotaColumns = `provider, day, used, daily_limit, remaining, reset_at`
+
+type PostgresQuotaRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresQuotaRepository(db *sql.DB) *PostgresQuotaRepository {
+	return &PostgresQuotaRepository{
+		db: db,
+	}
+}
+
+func (r *PostgresQuotaRepository) Reserve(ctx context.Context, provider, day string, units, limit int) (bool, *entity.QuotaUsage, error) {
+	query := `
+		INSERT INTO provider_quota (provider, day, used, daily_limit)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, day) DO UPDATE
+		SET used = provider_quota.used + EXCLUDED.used,
+			daily_limit = EXCLUDED.daily_limit,
+			remaining = CASE
+				WHEN provider_quota.reset_at <= NOW() THEN NULL
+				ELSE provider_quota.remaining - EXCLUDED.used
+			END,
+			reset_at = CASE
+				WHEN provider_quota.reset_at <= NOW() THEN NULL
+				ELSE provider_quota.reset_at
+			END,
+			updated_at = NOW()
+		WHERE (EXCLUDED.daily_limit = 0 OR provider_quota.used + EXCLUDED.used <= EXCLUDED.daily_limit)
+			AND (provider_quota.remaining IS NULL OR provider_quota.remaining >= EXCLUDED.used OR provider_quota.reset_at <= NOW())
+		RETURNING ` + quotaColumns
+
+	usage, err := scanQuotaUsage(r.db.QueryRowContext(ctx, query, provider, day, units, limit))
+	if err == nil {
+		return true, usage, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, nil, fmt.Errorf("failed to reserve quota: %w", err)
+	}
+
+	query = `
+		SELECT ` + quotaColumns + `
+		FROM provider_quota
+		WHERE provider = $1 AND day = $2
+	`
+
+	usage, err = scanQuotaUsage(r.db.QueryRowContext(ctx, query, provider, day))
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to get quota usage: %w", err)
+	}
+
+	return false, usage, nil
+}
+
+func (r *PostgresQuotaRepository) Observe(ctx context.Context, provider, day string, remaining int, resetAt time.Time) error {
+	query := `
+		INSERT INTO provider_quota (provider, day, remaining, reset_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, day) DO UPDATE
+		SET remaining = EXCLUDED.remaining,
+			reset_at = EXCLUDED.reset_at,
+			updated_at = NOW()
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, provider, day, remaining, resetAt); err != nil {
+		return fmt.Errorf("failed to record quota headers: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresQuotaRepository) Usage(ctx context.Context, day string) ([]*entity.QuotaUsage, error) {
+	query := `
+		SELECT ` + quotaColumns + `
+		FROM provider_quota
+		WHERE day = $1
+		ORDER BY provider
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quota usage: %w", err)
+	}
+	defer rows.Close()
+
+	var usages []*entity.QuotaUsage
+	for rows.Next() {
+		usage, err := scanQuotaUsage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan quota usage: %w", err)
+		}
+		usages = append(usages, usage)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating quota usage: %w", err)
+	}
+
+	return usages, nil
+}
+
+func scanQuotaUsage(row rowScanner) (*entity.QuotaUsage, error) {
+	var (
+		usage     entity.QuotaUsage
+		day       time.Time
+		remaining sql.NullInt64
+		resetAt   sql.NullTime
+	)
+
+	err := row.Scan(
+		&usage.Provider,
+		&day,
+		&usage.Used,
+		&usage.Limit,
+		&remaining,
+		&resetAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	usage.Day = day.Format(time.DateOnly)
+	if remaining.Valid {
+		value := int(remaining.Int64)
+		usage.Remaining = &value
+	}
+	if resetAt.Valid {
+		usage.ResetAt = &resetAt.Time
+	}
+
+	return &usage, nil
+}
@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+func (r *PostgresRepository) Suggest(ctx context.Context, field entity.FilterField, prefix string, limit int) ([]*entity.Suggestion, error) {
+	column, ok := filterColumns[field]
+	if !ok || !entity.SuggestFields[field] {
+		return nil, fmt.Errorf("%w: cannot suggest %q", entity.ErrInvalidFilter, field)
+	}
+
+	query := `
+		SELECT value, count
+		FROM name_suggestions
+		WHERE field = $1 AND key LIKE lower($2)
+		ORDER BY count DESC, key
+		LIMIT $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, column, escapeLike(prefix)+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest %s: %w", field, err)
+	}
+	defer rows.Close()
+
+	var suggestions []*entity.Suggestion
+	for rows.Next() {
+		suggestion := &entity.Suggestion{}
+		if err := rows.Scan(&suggestion.Value, &suggestion.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan suggestion: %w", err)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
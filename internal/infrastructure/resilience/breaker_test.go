@@ -0,0 +1,147 @@
+package resilience
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	type step struct {
+		action    string
+		wantErr   error
+		wantState State
+	}
+
+	tests := []struct {
+		name      string
+		threshold int
+		cooldown  time.Duration
+		steps     []step
+	}{
+		{
+			name:      "opens at threshold",
+			threshold: 2,
+			cooldown:  time.Hour,
+			steps: []step{
+				{action: "failure", wantState: StateClosed},
+				{action: "failure", wantState: StateOpen},
+				{action: "allow", wantErr: ErrCircuitOpen, wantState: StateOpen},
+			},
+		},
+		{
+			name:      "success resets failures",
+			threshold: 2,
+			cooldown:  time.Hour,
+			steps: []step{
+				{action: "failure", wantState: StateClosed},
+				{action: "success", wantState: StateClosed},
+				{action: "failure", wantState: StateClosed},
+				{action: "allow", wantState: StateClosed},
+			},
+		},
+		{
+			name:      "zero threshold never opens",
+			threshold: 0,
+			cooldown:  time.Hour,
+			steps: []step{
+				{action: "failure", wantState: StateClosed},
+				{action: "failure", wantState: StateClosed},
+				{action: "allow", wantState: StateClosed},
+			},
+		},
+		{
+			name:      "half-open admits a single probe",
+			threshold: 1,
+			cooldown:  0,
+			steps: []step{
+				{action: "failure", wantState: StateHalfOpen},
+				{action: "allow", wantState: StateHalfOpen},
+				{action: "allow", wantErr: ErrCircuitOpen, wantState: StateHalfOpen},
+			},
+		},
+		{
+			name:      "successful probe closes",
+			threshold: 1,
+			cooldown:  0,
+			steps: []step{
+				{action: "failure", wantState: StateHalfOpen},
+				{action: "allow", wantState: StateHalfOpen},
+				{action: "success", wantState: StateClosed},
+				{action: "allow", wantState: StateClosed},
+			},
+		},
+		{
+			name:      "failed probe reopens",
+			threshold: 5,
+			cooldown:  0,
+			steps: []step{
+				{action: "failure", wantState: StateClosed},
+				{action: "failure", wantState: StateClosed},
+				{action: "failure", wantState: StateClosed},
+				{action: "failure", wantState: StateClosed},
+				{action: "failure", wantState: StateHalfOpen},
+				{action: "allow", wantState: StateHalfOpen},
+				{action: "failure", wantState: StateHalfOpen},
+				{action: "allow", wantState: StateHalfOpen},
+			},
+		},
+		{
+			name:      "released probe frees the slot",
+			threshold: 1,
+			cooldown:  0,
+			steps: []step{
+				{action: "failure", wantState: StateHalfOpen},
+				{action: "allow", wantState: StateHalfOpen},
+				{action: "release", wantState: StateHalfOpen},
+				{action: "allow", wantState: StateHalfOpen},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewCircuitBreaker(tt.threshold, tt.cooldown)
+
+			for i, s := range tt.steps {
+				var err error
+				switch s.action {
+				case "allow":
+					err = b.Allow()
+				case "success":
+					b.Success()
+				case "failure":
+					b.Failure()
+				case "release":
+					b.Release()
+				}
+
+				if !errors.Is(err, s.wantErr) {
+					t.Fatalf("step %d (%s): error = %v, want %v", i, s.action, err, s.wantErr)
+				}
+				if got := b.Snapshot().State; got != s.wantState {
+					t.Fatalf("step %d (%s): state = %s, want %s", i, s.action, got, s.wantState)
+				}
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerFailedProbeRestartsCooldown(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Hour)
+	b.Failure()
+
+	// Pretend the cooldown has elapsed so the next call becomes the probe.
+	b.openedAt = time.Now().Add(-2 * time.Hour)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("probe Allow() error = %v", err)
+	}
+
+	b.Failure()
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Allow() after failed probe error = %v, want ErrCircuitOpen", err)
+	}
+	if got := b.Snapshot().State; got != StateOpen {
+		t.Errorf("state = %s, want %s", got, StateOpen)
+	}
+}
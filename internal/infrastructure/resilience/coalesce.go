@@ -0,0 +1,170 @@
+package resilience
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type flight[T any] struct {
+	ctx      context.Context
+	done     chan struct{}
+	cancel   context.CancelCauseFunc
+	mu       sync.Mutex
+	deadline *time.Timer
+	until    time.Time
+	waiters  int
+	result   T
+	err      error
+}
+
+func newFlight[T any](ctx context.Context) *flight[T] {
+	shared, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	f := &flight[T]{ctx: shared, done: make(chan struct{}), cancel: cancel, waiters: 1}
+
+	if until, ok := ctx.Deadline(); ok {
+		f.until = until
+		f.deadline = time.AfterFunc(time.Until(until), func() {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			cancel(context.DeadlineExceeded)
+		})
+	}
+
+	return f
+}
+
+func (f *flight[T]) join(ctx context.Context) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.ctx.Err() != nil {
+		return false
+	}
+
+	if f.deadline != nil {
+		until, ok := ctx.Deadline()
+		switch {
+		case !ok:
+			if !f.deadline.Stop() {
+				return false
+			}
+			f.deadline = nil
+		case until.After(f.until):
+			if !f.deadline.Reset(time.Until(until)) {
+				return false
+			}
+			f.until = until
+		}
+	}
+
+	f.waiters++
+	return true
+}
+
+func (f *flight[T]) stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.deadline != nil {
+		f.deadline.Stop()
+	}
+	f.cancel(nil)
+}
+
+type Coalescer[T any] struct {
+	mu      sync.Mutex
+	flights map[string]*flight[T]
+}
+
+func NewCoalescer[T any]() *Coalescer[T] {
+	return &Coalescer[T]{
+		flights: make(map[string]*flight[T]),
+	}
+}
+
+func (c *Coalescer[T]) Do(ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	c.mu.Lock()
+	f, ok := c.flights[key]
+	if !ok || !f.join(ctx) {
+		f = newFlight[T](ctx)
+		c.flights[key] = f
+
+		go func() {
+			result, err := fn(f.ctx)
+
+			c.mu.Lock()
+			f.result, f.err = result, err
+			if c.flights[key] == f {
+				delete(c.flights, key)
+			}
+			f.stop()
+			c.mu.Unlock()
+
+			close(f.done)
+		}()
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		c.leave(key, f)
+		var zero T
+		return zero, ctx.Err()
+	case <-f.done:
+		if f.err != nil && context.Cause(f.ctx) == context.DeadlineExceeded {
+			return f.result, context.DeadlineExceeded
+		}
+		return f.result, f.err
+	}
+}
+
+func (c *Coalescer[T]) leave(key string, f *flight[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f.waiters--
+	if f.waiters > 0 {
+		return
+	}
+
+	if c.flights[key] == f {
+		delete(c.flights, key)
+	}
+	f.stop()
+}
+
+type Limiter struct {
+	slots chan struct{}
+}
+
+func NewLimiter(limit int) *Limiter {
+	if limit <= 0 {
+		return &Limiter{}
+	}
+
+	return &Limiter{
+		slots: make(chan struct{}, limit),
+	}
+}
+
+func (l *Limiter) Acquire(ctx context.Context) error {
+	if l.slots == nil {
+		return nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *Limiter) Release() {
+	if l.slots == nil {
+		return
+	}
+
+	<-l.slots
+}
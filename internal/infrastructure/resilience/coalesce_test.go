@@ -0,0 +1,245 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalescerSharesResult(t *testing.T) {
+	c := NewCoalescer[int]()
+	release := make(chan struct{})
+	var calls atomic.Int32
+
+	fn := func(ctx context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = c.Do(context.Background(), "ivan", fn)
+		}()
+	}
+
+	waitForWaiters(t, c, "ivan", len(results))
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Fatalf("fn called %d times, want 1", calls.Load())
+	}
+	for i, result := range results {
+		if result != 42 {
+			t.Errorf("result[%d] = %d, want 42", i, result)
+		}
+	}
+}
+
+func TestCoalescerCancellation(t *testing.T) {
+	tests := []struct {
+		name       string
+		waiters    int
+		cancelled  int
+		wantCancel bool
+	}{
+		{name: "single waiter leaves", waiters: 1, cancelled: 1, wantCancel: true},
+		{name: "all waiters leave", waiters: 3, cancelled: 3, wantCancel: true},
+		{name: "one waiter remains", waiters: 3, cancelled: 2, wantCancel: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCoalescer[int]()
+			started := make(chan context.Context, 1)
+			release := make(chan struct{})
+			finished := make(chan error, 1)
+
+			fn := func(ctx context.Context) (int, error) {
+				started <- ctx
+				select {
+				case <-ctx.Done():
+					finished <- ctx.Err()
+					return 0, ctx.Err()
+				case <-release:
+					finished <- nil
+					return 1, nil
+				}
+			}
+
+			cancels := make([]context.CancelFunc, tt.waiters)
+			errs := make(chan error, tt.waiters)
+			for i := range cancels {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancels[i] = cancel
+				go func() {
+					_, err := c.Do(ctx, "ivan", fn)
+					errs <- err
+				}()
+			}
+			<-started
+			waitForWaiters(t, c, "ivan", tt.waiters)
+
+			for i := 0; i < tt.cancelled; i++ {
+				cancels[i]()
+				if err := <-errs; !errors.Is(err, context.Canceled) {
+					t.Fatalf("cancelled waiter got %v, want context.Canceled", err)
+				}
+			}
+
+			if !tt.wantCancel {
+				close(release)
+			}
+
+			select {
+			case err := <-finished:
+				if gotCancel := err != nil; gotCancel != tt.wantCancel {
+					t.Fatalf("shared call cancelled = %v, want %v", gotCancel, tt.wantCancel)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("shared call did not finish")
+			}
+
+			for i := tt.cancelled; i < tt.waiters; i++ {
+				if err := <-errs; err != nil {
+					t.Errorf("remaining waiter got %v, want nil", err)
+				}
+			}
+			for _, cancel := range cancels {
+				cancel()
+			}
+		})
+	}
+}
+
+func TestCoalescerUsesLatestDeadline(t *testing.T) {
+	c := NewCoalescer[int]()
+	release := make(chan struct{})
+
+	fn := func(ctx context.Context) (int, error) {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-release:
+			return 42, nil
+		}
+	}
+
+	short, cancelShort := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelShort()
+	long, cancelLong := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelLong()
+
+	shortErr := make(chan error, 1)
+	go func() {
+		_, err := c.Do(short, "ivan", fn)
+		shortErr <- err
+	}()
+	waitForWaiters(t, c, "ivan", 1)
+
+	longResult := make(chan int, 1)
+	go func() {
+		result, err := c.Do(long, "ivan", fn)
+		if err != nil {
+			t.Errorf("waiter with the later deadline got %v, want nil", err)
+		}
+		longResult <- result
+	}()
+	waitForWaiters(t, c, "ivan", 2)
+
+	if err := <-shortErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("waiter with the earlier deadline got %v, want context.DeadlineExceeded", err)
+	}
+
+	close(release)
+	if result := <-longResult; result != 42 {
+		t.Errorf("result = %d, want 42", result)
+	}
+}
+
+func TestCoalescerDeadlineExpires(t *testing.T) {
+	c := NewCoalescer[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Do(ctx, "ivan", func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Do() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestCoalescerDoesNotJoinExpiredFlight(t *testing.T) {
+	c := NewCoalescer[int]()
+	short, cancelShort := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelShort()
+
+	var calls atomic.Int32
+	fn := func(ctx context.Context) (int, error) {
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			time.Sleep(20 * time.Millisecond)
+			return 0, ctx.Err()
+		}
+		return 42, nil
+	}
+
+	shortErr := make(chan error, 1)
+	go func() {
+		_, err := c.Do(short, "ivan", fn)
+		shortErr <- err
+	}()
+	waitForWaiters(t, c, "ivan", 1)
+	<-short.Done()
+
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		c.mu.Lock()
+		f := c.flights["ivan"]
+		expired := f != nil && f.ctx.Err() != nil
+		c.mu.Unlock()
+		if expired {
+			break
+		}
+	}
+
+	long, cancelLong := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelLong()
+
+	result, err := c.Do(long, "ivan", fn)
+	if err != nil || result != 42 {
+		t.Fatalf("Do() after the flight expired = %d, %v, want 42, nil", result, err)
+	}
+	if err := <-shortErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expired waiter got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func waitForWaiters[T any](t *testing.T, c *Coalescer[T], key string, want int) {
+	t.Helper()
+
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(time.Millisecond) {
+		c.mu.Lock()
+		f, ok := c.flights[key]
+		got := 0
+		if ok {
+			got = f.waiters
+		}
+		c.mu.Unlock()
+
+		if got == want {
+			return
+		}
+	}
+
+	t.Fatalf("timed out waiting for %d waiters on %q", want, key)
+}
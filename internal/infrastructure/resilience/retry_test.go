@@ -0,0 +1,91 @@
+package resilience
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{name: "no base delay", policy: RetryPolicy{}, attempt: 3, min: 0, max: 0},
+		{
+			name:    "first attempt",
+			policy:  RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second},
+			attempt: 0,
+			min:     50 * time.Millisecond,
+			max:     100 * time.Millisecond,
+		},
+		{
+			name:    "exponential growth",
+			policy:  RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second},
+			attempt: 2,
+			min:     200 * time.Millisecond,
+			max:     400 * time.Millisecond,
+		},
+		{
+			name:    "capped at max delay",
+			policy:  RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second},
+			attempt: 10,
+			min:     500 * time.Millisecond,
+			max:     time.Second,
+		},
+		{
+			name:    "overflow falls back to max delay",
+			policy:  RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second},
+			attempt: 80,
+			min:     2500 * time.Millisecond,
+			max:     5 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				got := tt.policy.Backoff(tt.attempt)
+				if got < tt.min || got > tt.max {
+					t.Fatalf("Backoff(%d) = %s, want within [%s, %s]", tt.attempt, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	past := time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)
+
+	tests := []struct {
+		name   string
+		header string
+		min    time.Duration
+		max    time.Duration
+		wantOK bool
+	}{
+		{name: "empty", header: ""},
+		{name: "seconds", header: "30", min: 30 * time.Second, max: 30 * time.Second, wantOK: true},
+		{name: "zero seconds", header: "0", wantOK: true},
+		{name: "negative seconds", header: "-5"},
+		{name: "http date", header: future, min: 58 * time.Second, max: time.Minute, wantOK: true},
+		{name: "http date in the past", header: past, wantOK: true},
+		{name: "garbage", header: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseRetryAfter(tt.header)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseRetryAfter(%q) ok = %v, want %v", tt.header, ok, tt.wantOK)
+			}
+			if got < tt.min || got > tt.max {
+				t.Errorf("ParseRetryAfter(%q) = %s, want within [%s, %s]", tt.header, got, tt.min, tt.max)
+			}
+		})
+	}
+}
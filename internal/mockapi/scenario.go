@@ -0,0 +1,151 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	ProviderAgify       = "agify"
+	ProviderGenderize   = "genderize"
+	ProviderNationalize = "nationalize"
+)
+
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("duration must be a string like \"250ms\": %w", err)
+	}
+
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		return fmt.Errorf("failed to parse duration: %w", err)
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+type Country struct {
+	CountryID   string  `json:"country_id"`
+	Probability float64 `json:"probability"`
+}
+
+type Answer struct {
+	Age              *int      `json:"age"`
+	AgeCount         int       `json:"age_count"`
+	Gender           *string   `json:"gender"`
+	Probability      float64   `json:"probability"`
+	GenderCount      int       `json:"gender_count"`
+	Countries        []Country `json:"countries"`
+	NationalityCount int       `json:"nationality_count"`
+}
+
+type RateLimit struct {
+	Limit      int      `json:"limit"`
+	Window     Duration `json:"window"`
+	RetryAfter Duration `json:"retry_after"`
+}
+
+type Failures struct {
+	Status int `json:"status"`
+	Burst  int `json:"burst"`
+	Every  int `json:"every"`
+}
+
+type Behavior struct {
+	Latency   Duration   `json:"latency"`
+	Jitter    Duration   `json:"jitter"`
+	RateLimit *RateLimit `json:"rate_limit"`
+	Failures  *Failures  `json:"failures"`
+}
+
+type Scenario struct {
+	Names     map[string]Answer   `json:"names"`
+	Default   *Answer             `json:"default"`
+	Providers map[string]Behavior `json:"providers"`
+}
+
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	return ParseScenario(data)
+}
+
+func ParseScenario(data []byte) (*Scenario, error) {
+	var scenario Scenario
+	if err := json.Unmarshal(data, &scenario); err != nil {
+		return nil, fmt.Errorf("failed to decode scenario: %w", err)
+	}
+
+	if err := scenario.normalize(); err != nil {
+		return nil, err
+	}
+
+	return &scenario, nil
+}
+
+func (s *Scenario) normalize() error {
+	names := make(map[string]Answer, len(s.Names))
+	for name, answer := range s.Names {
+		names[normalizeName(name)] = answer
+	}
+	s.Names = names
+
+	if s.Providers == nil {
+		s.Providers = map[string]Behavior{}
+	}
+
+	for provider, behavior := range s.Providers {
+		switch provider {
+		case ProviderAgify, ProviderGenderize, ProviderNationalize:
+		default:
+			return fmt.Errorf("unknown provider %q", provider)
+		}
+
+		if behavior.RateLimit != nil && behavior.RateLimit.Limit < 1 {
+			return fmt.Errorf("%s: rate_limit.limit must be positive", provider)
+		}
+		if behavior.RateLimit != nil && behavior.RateLimit.Window <= 0 {
+			behavior.RateLimit.Window = Duration(24 * time.Hour)
+		}
+
+		if behavior.Failures != nil && behavior.Failures.Status == 0 {
+			behavior.Failures.Status = 503
+		}
+		if behavior.Failures != nil && behavior.Failures.Every > 0 && behavior.Failures.Burst > behavior.Failures.Every {
+			return fmt.Errorf("%s: failures.burst must not exceed failures.every", provider)
+		}
+
+		s.Providers[provider] = behavior
+	}
+
+	return nil
+}
+
+func (s *Scenario) answer(name string) Answer {
+	if answer, ok := s.Names[normalizeName(name)]; ok {
+		return answer
+	}
+	if s.Default != nil {
+		return *s.Default
+	}
+
+	return Answer{}
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,274 @@
+package mockapi
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type agifyResponse struct {
+	Count     int    `json:"count"`
+	Name      string `json:"name"`
+	Age       *int   `json:"age"`
+	CountryID string `json:"country_id,omitempty"`
+}
+
+type genderizeResponse struct {
+	Count       int     `json:"count"`
+	Name        string  `json:"name"`
+	Gender      *string `json:"gender"`
+	Probability float64 `json:"probability"`
+	CountryID   string  `json:"country_id,omitempty"`
+}
+
+type nationalizeResponse struct {
+	Count   int       `json:"count"`
+	Name    string    `json:"name"`
+	Country []Country `json:"country"`
+}
+
+type ProviderStats struct {
+	Requests    int `json:"requests"`
+	Names       int `json:"names"`
+	Failures    int `json:"failures"`
+	RateLimited int `json:"rate_limited"`
+}
+
+type providerState struct {
+	stats       ProviderStats
+	windowStart time.Time
+	used        int
+}
+
+type Server struct {
+	mu       sync.Mutex
+	scenario *Scenario
+	state    map[string]*providerState
+}
+
+func NewServer(scenario *Scenario) *Server {
+	s := &Server{}
+	s.reset(scenario)
+	return s
+}
+
+func (s *Server) reset(scenario *Scenario) {
+	s.scenario = scenario
+	s.state = map[string]*providerState{
+		ProviderAgify:       {},
+		ProviderGenderize:   {},
+		ProviderNationalize: {},
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	router := gin.Default()
+
+	router.GET("/agify/", s.provider(ProviderAgify, agify))
+	router.GET("/genderize/", s.provider(ProviderGenderize, genderize))
+	router.GET("/nationalize/", s.provider(ProviderNationalize, nationalize))
+
+	router.GET("/_scenario", s.getScenario)
+	router.PUT("/_scenario", s.putScenario)
+	router.GET("/_stats", s.getStats)
+
+	return router
+}
+
+func agify(name, countryID string, answer Answer) interface{} {
+	return agifyResponse{
+		Count:     answer.AgeCount,
+		Name:      name,
+		Age:       answer.Age,
+		CountryID: countryID,
+	}
+}
+
+func genderize(name, countryID string, answer Answer) interface{} {
+	return genderizeResponse{
+		Count:       answer.GenderCount,
+		Name:        name,
+		Gender:      answer.Gender,
+		Probability: answer.Probability,
+		CountryID:   countryID,
+	}
+}
+
+func nationalize(name, _ string, answer Answer) interface{} {
+	countries := answer.Countries
+	if countries == nil {
+		countries = []Country{}
+	}
+
+	return nationalizeResponse{
+		Count:   answer.NationalityCount,
+		Name:    name,
+		Country: countries,
+	}
+}
+
+func (s *Server) provider(name string, render func(name, countryID string, answer Answer) interface{}) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		names, batch := c.GetQueryArray("name[]")
+		if !batch {
+			single, ok := c.GetQuery("name")
+			if !ok || single == "" {
+				c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Missing 'name' parameter"})
+				return
+			}
+			names = []string{single}
+		}
+
+		if len(names) > 10 {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Invalid 'name' parameter"})
+			return
+		}
+
+		s.mu.Lock()
+		scenario := s.scenario
+		behavior := scenario.Providers[name]
+		state := s.state[name]
+		state.stats.Requests++
+		state.stats.Names += len(names)
+		index := state.stats.Requests - 1
+		s.mu.Unlock()
+
+		if !sleep(c, behavior) {
+			return
+		}
+
+		if failures := behavior.Failures; failures != nil && injectFailure(failures, index) {
+			s.mu.Lock()
+			state.stats.Failures++
+			s.mu.Unlock()
+
+			c.JSON(failures.Status, gin.H{"error": http.StatusText(failures.Status)})
+			return
+		}
+
+		if limit := behavior.RateLimit; limit != nil && !s.consume(c, state, limit, len(names)) {
+			return
+		}
+
+		countryID := c.Query("country_id")
+
+		if !batch {
+			c.JSON(http.StatusOK, render(names[0], countryID, scenario.answer(names[0])))
+			return
+		}
+
+		results := make([]interface{}, len(names))
+		for i, n := range names {
+			results[i] = render(n, countryID, scenario.answer(n))
+		}
+		c.JSON(http.StatusOK, results)
+	}
+}
+
+func sleep(c *gin.Context, behavior Behavior) bool {
+	delay := time.Duration(behavior.Latency)
+	if behavior.Jitter > 0 {
+		delay += rand.N(time.Duration(behavior.Jitter))
+	}
+	if delay <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-c.Request.Context().Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func injectFailure(failures *Failures, index int) bool {
+	if failures.Every > 0 {
+		return index%failures.Every < failures.Burst
+	}
+
+	return index < failures.Burst
+}
+
+func (s *Server) consume(c *gin.Context, state *providerState, limit *RateLimit, cost int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	window := time.Duration(limit.Window)
+	if state.windowStart.IsZero() || now.Sub(state.windowStart) >= window {
+		state.windowStart = now
+		state.used = 0
+	}
+
+	reset := state.windowStart.Add(window).Sub(now)
+	resetSeconds := strconv.Itoa(int(reset.Round(time.Second) / time.Second))
+
+	c.Header("X-Rate-Limit-Limit", strconv.Itoa(limit.Limit))
+
+	if state.used+cost > limit.Limit {
+		state.stats.RateLimited++
+
+		retryAfter := resetSeconds
+		if limit.RetryAfter > 0 {
+			retryAfter = strconv.Itoa(int(time.Duration(limit.RetryAfter) / time.Second))
+		}
+
+		c.Header("X-Rate-Limit-Remaining", strconv.Itoa(limit.Limit-state.used))
+		c.Header("X-Rate-Limit-Reset", resetSeconds)
+		c.Header("Retry-After", retryAfter)
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Request limit reached"})
+		return false
+	}
+
+	state.used += cost
+	c.Header("X-Rate-Limit-Remaining", strconv.Itoa(limit.Limit-state.used))
+	c.Header("X-Rate-Limit-Reset", resetSeconds)
+	return true
+}
+
+func (s *Server) getScenario(c *gin.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c.JSON(http.StatusOK, s.scenario)
+}
+
+func (s *Server) putScenario(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scenario, err := ParseScenario(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	s.reset(scenario)
+	s.mu.Unlock()
+
+	c.JSON(http.StatusOK, scenario)
+}
+
+func (s *Server) getStats(c *gin.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := make(map[string]ProviderStats, len(s.state))
+	for name, state := range s.state {
+		stats[name] = state.stats
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
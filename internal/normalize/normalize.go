@@ -0,0 +1,80 @@
+package normalize
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	SchemeNone = "none"
+	SchemeBGN  = "bgn"
+	SchemeICAO = "icao"
+	SchemeGOST = "gost"
+)
+
+var common = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ж': "zh", 'з': "z",
+	'и': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ы': "y", 'ь': "", 'ъ': "", 'э': "e", 'ґ': "g", 'і': "i",
+}
+
+var schemes = map[string]map[rune]string{
+	SchemeBGN: {
+		'ё': "e", 'й': "y", 'х': "kh", 'ц': "ts", 'ю': "yu", 'я': "ya", 'є': "ye", 'ї': "yi",
+	},
+	SchemeICAO: {
+		'ё': "e", 'й': "i", 'х': "kh", 'ц': "ts", 'ю': "iu", 'я': "ia", 'є': "ie", 'ї': "i", 'ъ': "ie",
+	},
+	SchemeGOST: {
+		'ё': "e", 'й': "i", 'х': "kh", 'ц': "tc", 'ю': "iu", 'я': "ia", 'є': "ie", 'ї': "i",
+	},
+}
+
+type Normalizer struct {
+	table map[rune]string
+}
+
+func New(scheme string) (*Normalizer, error) {
+	if scheme == SchemeNone {
+		return &Normalizer{}, nil
+	}
+
+	overrides, ok := schemes[scheme]
+	if !ok {
+		return nil, fmt.Errorf("unknown transliteration scheme %q", scheme)
+	}
+
+	table := make(map[rune]string, len(common)+len(overrides))
+	for r, latin := range common {
+		table[r] = latin
+	}
+	for r, latin := range overrides {
+		table[r] = latin
+	}
+
+	return &Normalizer{table: table}, nil
+}
+
+func (n *Normalizer) Normalize(name string) string {
+	folded := strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}), " ")
+
+	if n.table == nil {
+		return folded
+	}
+
+	var b strings.Builder
+	b.Grow(len(folded))
+	for _, r := range folded {
+		if latin, ok := n.table[r]; ok {
+			b.WriteString(latin)
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package normalize
+
+import (
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for _, scheme := range []string{SchemeNone, SchemeBGN, SchemeICAO, SchemeGOST} {
+		if _, err := New(scheme); err != nil {
+			t.Errorf("New(%q) error = %v", scheme, err)
+		}
+	}
+
+	if _, err := New("iso9"); err == nil {
+		t.Error("New(\"iso9\") error = nil, want unknown scheme")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		scheme string
+		name   string
+		want   string
+	}{
+		{scheme: SchemeNone, name: "  Дмитрий \t Юрьевич ", want: "дмитрий юрьевич"},
+		{scheme: SchemeNone, name: "ALEX", want: "alex"},
+		{scheme: SchemeBGN, name: "Дмитрий", want: "dmitriy"},
+		{scheme: SchemeBGN, name: "Юлия", want: "yuliya"},
+		{scheme: SchemeBGN, name: "Цой", want: "tsoy"},
+		{scheme: SchemeBGN, name: "Щукин", want: "shchukin"},
+		{scheme: SchemeBGN, name: "Фёдор", want: "fedor"},
+		{scheme: SchemeBGN, name: "Ігор", want: "igor"},
+		{scheme: SchemeBGN, name: "Ярослав", want: "yaroslav"},
+		{scheme: SchemeICAO, name: "Дмитрий", want: "dmitrii"},
+		{scheme: SchemeICAO, name: "Юлия", want: "iuliia"},
+		{scheme: SchemeICAO, name: "Подъячев", want: "podieiachev"},
+		{scheme: SchemeGOST, name: "Цой", want: "tcoi"},
+		{scheme: SchemeGOST, name: "Хрущёв", want: "khrushchev"},
+		{scheme: SchemeBGN, name: "Anna-Maria", want: "anna-maria"},
+		{scheme: SchemeBGN, name: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scheme+"/"+tt.name, func(t *testing.T) {
+			n, err := New(tt.scheme)
+			if err != nil {
+				t.Fatalf("New(%q) error = %v", tt.scheme, err)
+			}
+			if got := n.Normalize(tt.name); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
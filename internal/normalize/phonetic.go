@@ -0,0 +1,88 @@
+package normalize
+
+import (
+	"strings"
+	"unicode"
+)
+
+var phoneticLatin, _ = New(SchemeBGN)
+
+var phoneticGroups = []struct {
+	from string
+	to   byte
+}{
+	{"shch", 'S'}, {"sch", 'S'}, {"tch", 'C'}, {"dzh", 'J'},
+	{"zh", 'J'}, {"sh", 'S'}, {"ch", 'C'}, {"kh", 'H'}, {"ts", 'C'}, {"tz", 'C'}, {"cz", 'C'},
+	{"ph", 'F'}, {"th", 'T'}, {"ck", 'K'}, {"dj", 'J'},
+}
+
+var phoneticLetters = map[byte]string{
+	'b': "B", 'c': "K", 'd': "D", 'f': "F", 'g': "G", 'h': "H", 'k': "K", 'l': "L", 'm': "M",
+	'n': "N", 'p': "P", 'q': "K", 'r': "R", 's': "S", 't': "T", 'v': "V", 'w': "V", 'x': "KS", 'z': "Z",
+}
+
+func (n *Normalizer) Phonetic(name string) string {
+	return Phonetic(name)
+}
+
+func Phonetic(name string) string {
+	words := strings.FieldsFunc(phoneticLatin.Normalize(name), func(r rune) bool {
+		return r > unicode.MaxASCII || !unicode.IsLetter(r)
+	})
+
+	keys := make([]string, 0, len(words))
+	for _, word := range words {
+		if key := phoneticWord(word); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	return strings.Join(keys, " ")
+}
+
+func phoneticWord(word string) string {
+	if strings.HasSuffix(word, "ff") {
+		word = word[:len(word)-2] + "v"
+	}
+
+	var key []byte
+	emit := func(code string) {
+		for i := 0; i < len(code); i++ {
+			if len(key) == 0 || key[len(key)-1] != code[i] {
+				key = append(key, code[i])
+			}
+		}
+	}
+
+	for i := 0; i < len(word); {
+		if isPhoneticVowel(word[i]) {
+			if i == 0 {
+				key = append(key, 'A')
+			}
+			i++
+			continue
+		}
+
+		matched := false
+		for _, group := range phoneticGroups {
+			if strings.HasPrefix(word[i:], group.from) {
+				emit(string(group.to))
+				i += len(group.from)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		emit(phoneticLetters[word[i]])
+		i++
+	}
+
+	return string(key)
+}
+
+func isPhoneticVowel(c byte) bool {
+	return strings.IndexByte("aeiouyj", c) >= 0
+}
@@ -0,0 +1,29 @@
+package normalize
+
+import "testing"
+
+func TestPhonetic(t *testing.T) {
+	tests := []struct {
+		names []string
+		want  string
+	}{
+		{names: []string{"Dmitry", "Dmitrii", "Dmitriy", "Дмитрий"}, want: "DMTR"},
+		{names: []string{"Yuriy", "Jurij", "Юрий"}, want: "AR"},
+		{names: []string{"Aleksandr", "Alexandr", "Александр"}, want: "ALKSNDR"},
+		{names: []string{"Tchaikovsky", "Chaykovskiy", "Чайковский"}, want: "CKVSK"},
+		{names: []string{"Ivanov", "Ivanoff", "Иванов"}, want: "AVNV"},
+		{names: []string{"Khrushchev", "Хрущёв"}, want: "HRSV"},
+		{names: []string{"Иван Петров"}, want: "AVN PTRV"},
+		{names: []string{"", "123", "  "}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			for _, name := range tt.names {
+				if got := Phonetic(name); got != tt.want {
+					t.Errorf("Phonetic(%q) = %q, want %q", name, got, tt.want)
+				}
+			}
+		})
+	}
+}
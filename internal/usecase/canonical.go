@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+)
+
+const canonicalBackfillBatch = 500
+
+// canonicalVersion is bumped whenever normalization, diminutive resolution or
+// phonetic keys change, so the backfill recomputes rows written by older code.
+const canonicalVersion = 1
+
+func (uc *PersonUseCase) BackfillCanonical(ctx context.Context) error {
+	total := 0
+	for {
+		persons, err := uc.repo.GetUncanonicalized(ctx, canonicalVersion, canonicalBackfillBatch)
+		if err != nil {
+			return fmt.Errorf("failed to backfill canonical names: %w", err)
+		}
+		if len(persons) == 0 {
+			break
+		}
+
+		for _, person := range persons {
+			uc.enricher.canonicalize(person)
+			if err := uc.repo.UpdateCanonical(ctx, person); err != nil {
+				return fmt.Errorf("failed to backfill canonical names: %w", err)
+			}
+		}
+		total += len(persons)
+	}
+
+	if total > 0 {
+		uc.logger.Printf("Backfilled canonical names and phonetic keys for %d persons", total)
+	}
+	return nil
+}
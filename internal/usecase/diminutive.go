@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+type DiminutiveUseCase struct {
+	repo   entity.DiminutiveRepository
+	logger *log.Logger
+
+	mu    sync.RWMutex
+	index map[string]string
+}
+
+func NewDiminutiveUseCase(repo entity.DiminutiveRepository, logger *log.Logger) *DiminutiveUseCase {
+	return &DiminutiveUseCase{
+		repo:   repo,
+		logger: logger,
+		index:  map[string]string{},
+	}
+}
+
+func (uc *DiminutiveUseCase) Load(ctx context.Context) error {
+	diminutives, err := uc.repo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load diminutives: %w", err)
+	}
+
+	index := make(map[string]string, len(diminutives))
+	for _, diminutive := range diminutives {
+		index[dictionaryKey(diminutive.Diminutive)] = diminutive.Canonical
+	}
+
+	uc.mu.Lock()
+	uc.index = index
+	uc.mu.Unlock()
+
+	uc.logger.Printf("Loaded %d diminutives", len(diminutives))
+	return nil
+}
+
+func (uc *DiminutiveUseCase) Resolve(name string) (string, bool) {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	canonical, ok := uc.index[dictionaryKey(name)]
+	return canonical, ok
+}
+
+func (uc *DiminutiveUseCase) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			uc.reload(ctx)
+		}
+	}
+}
+
+func (uc *DiminutiveUseCase) List(ctx context.Context) ([]*entity.Diminutive, error) {
+	diminutives, err := uc.repo.List(ctx)
+	if err != nil {
+		uc.logger.Printf("Error listing diminutives: %v", err)
+		return nil, fmt.Errorf("failed to list diminutives: %w", err)
+	}
+
+	return diminutives, nil
+}
+
+func (uc *DiminutiveUseCase) Get(ctx context.Context, diminutive string) (*entity.Diminutive, error) {
+	result, err := uc.repo.Get(ctx, dictionaryKey(diminutive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diminutive: %w", err)
+	}
+
+	return result, nil
+}
+
+func (uc *DiminutiveUseCase) Create(ctx context.Context, diminutive, canonical string) (*entity.Diminutive, error) {
+	uc.logger.Printf("Creating diminutive %s -> %s", diminutive, canonical)
+
+	result := &entity.Diminutive{
+		Diminutive: dictionaryKey(diminutive),
+		Canonical:  strings.TrimSpace(canonical),
+	}
+
+	if err := uc.repo.Create(ctx, result); err != nil {
+		uc.logger.Printf("Error creating diminutive %s: %v", diminutive, err)
+		return nil, fmt.Errorf("failed to create diminutive: %w", err)
+	}
+
+	uc.reload(ctx)
+	return result, nil
+}
+
+func (uc *DiminutiveUseCase) Update(ctx context.Context, diminutive, canonical string) (*entity.Diminutive, error) {
+	uc.logger.Printf("Updating diminutive %s -> %s", diminutive, canonical)
+
+	result := &entity.Diminutive{
+		Diminutive: dictionaryKey(diminutive),
+		Canonical:  strings.TrimSpace(canonical),
+	}
+
+	if err := uc.repo.Update(ctx, result); err != nil {
+		uc.logger.Printf("Error updating diminutive %s: %v", diminutive, err)
+		return nil, fmt.Errorf("failed to update diminutive: %w", err)
+	}
+
+	uc.reload(ctx)
+	return result, nil
+}
+
+func (uc *DiminutiveUseCase) Delete(ctx context.Context, diminutive string) error {
+	uc.logger.Printf("Deleting diminutive %s", diminutive)
+
+	if err := uc.repo.Delete(ctx, dictionaryKey(diminutive)); err != nil {
+		uc.logger.Printf("Error deleting diminutive %s: %v", diminutive, err)
+		return fmt.Errorf("failed to delete diminutive: %w", err)
+	}
+
+	uc.reload(ctx)
+	return nil
+}
+
+func (uc *DiminutiveUseCase) reload(ctx context.Context) {
+	if err := uc.Load(ctx); err != nil {
+		uc.logger.Printf("Error reloading diminutives: %v", err)
+	}
+}
+
+func dictionaryKey(diminutive string) string {
+	return strings.ToLower(strings.TrimSpace(diminutive))
+}
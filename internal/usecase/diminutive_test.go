@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log"
+	"testing"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+type fakeDiminutives []*entity.Diminutive
+
+func (r fakeDiminutives) List(ctx context.Context) ([]*entity.Diminutive, error) {
+	return r, nil
+}
+
+func (r fakeDiminutives) Get(ctx context.Context, diminutive string) (*entity.Diminutive, error) {
+	return nil, entity.ErrDiminutiveNotFound
+}
+
+func (r fakeDiminutives) Create(ctx context.Context, diminutive *entity.Diminutive) error {
+	return nil
+}
+
+func (r fakeDiminutives) Update(ctx context.Context, diminutive *entity.Diminutive) error {
+	return nil
+}
+
+func (r fakeDiminutives) Delete(ctx context.Context, diminutive string) error {
+	return nil
+}
+
+func TestDiminutiveResolveKeepsScriptsApart(t *testing.T) {
+	repo := fakeDiminutives{
+		{Diminutive: "dima", Canonical: "Dmitriy"},
+		{Diminutive: "дима", Canonical: "Дмитрий"},
+	}
+
+	for range 2 {
+		uc := NewDiminutiveUseCase(repo, log.New(io.Discard, "", 0))
+		if err := uc.Load(context.Background()); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		tests := map[string]string{"Dima": "Dmitriy", " ДИМА ": "Дмитрий"}
+		for name, want := range tests {
+			if got, ok := uc.Resolve(name); !ok || got != want {
+				t.Errorf("Resolve(%q) = %q, %v, want %q", name, got, ok, want)
+			}
+		}
+
+		repo[0], repo[1] = repo[1], repo[0]
+	}
+}
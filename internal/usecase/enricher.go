@@ -0,0 +1,443 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+const (
+	GenderRulesFirst    = "first"
+	GenderRulesFallback = "fallback"
+	GenderRulesOff      = "off"
+)
+
+type EnricherConfig struct {
+	Thresholds             entity.Thresholds
+	GenderRules            string
+	GenderFallback         entity.GenderPredictor
+	DefaultCountry         string
+	CountryFromNationality bool
+	SurnameWeight          float64
+}
+
+type Enricher struct {
+	client     entity.ExternalAPIClient
+	surnames   entity.NationalityPredictor
+	normalizer entity.NameNormalizer
+	names      entity.NameResolver
+	logs       entity.EnrichmentLogRepository
+	cfg        EnricherConfig
+}
+
+func NewEnricher(client entity.ExternalAPIClient, surnames entity.NationalityPredictor, normalizer entity.NameNormalizer, names entity.NameResolver, logs entity.EnrichmentLogRepository, cfg EnricherConfig) *Enricher {
+	cfg.DefaultCountry = strings.ToUpper(cfg.DefaultCountry)
+
+	return &Enricher{
+		client:     client,
+		surnames:   surnames,
+		normalizer: normalizer,
+		names:      names,
+		logs:       logs,
+		cfg:        cfg,
+	}
+}
+
+func (e *Enricher) canonicalize(person *entity.Person) {
+	name := person.Name
+	person.ResolvedName = ""
+
+	if canonical, ok := e.names.Resolve(name); ok {
+		person.ResolvedName = canonical
+		name = canonical
+	}
+
+	person.CanonicalName = e.normalizer.Normalize(name)
+	person.Phonetic = e.phoneticKeys(person)
+	person.CanonicalVersion = canonicalVersion
+}
+
+func (e *Enricher) phoneticKeys(person *entity.Person) entity.PhoneticKeys {
+	return entity.PhoneticKeys{
+		Name:       e.normalizer.Phonetic(person.Name),
+		Surname:    e.normalizer.Phonetic(person.Surname),
+		Patronymic: e.normalizer.Phonetic(person.Patronymic),
+	}
+}
+
+func (e *Enricher) enrichPerson(ctx context.Context, person *entity.Person) (*entity.Enrichment, error) {
+	enrichment, err := e.lookup(ctx, person)
+	if err != nil {
+		return nil, err
+	}
+
+	e.cfg.Thresholds.Apply(enrichment)
+	return enrichment, nil
+}
+
+func (e *Enricher) lookup(ctx context.Context, person *entity.Person) (*entity.Enrichment, error) {
+	e.canonicalize(person)
+
+	enrichment := &entity.Enrichment{}
+	surname := e.lookupSurname(ctx, person)
+
+	country, localized, err := e.localize(ctx, person, enrichment, surname)
+	if err != nil {
+		return nil, err
+	}
+	person.LocalizationCountry = country
+
+	if e.cfg.GenderRules == GenderRulesFirst {
+		enrichment.Gender = inferGender(person.Surname, person.Patronymic, country)
+	}
+
+	if enrichment.Gender == nil && !localized {
+		enrichment, err = e.client.EnrichPerson(ctx, person.CanonicalName, country)
+	} else {
+		err = e.fetchRemaining(ctx, person.CanonicalName, country, enrichment, !localized)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if gender := e.rulesGender(person, enrichment); gender != nil && enrichment.Gender == nil {
+		enrichment = withGender(enrichment, gender)
+	}
+	enrichment = e.fallbackGender(ctx, person.CanonicalName, country, enrichment)
+
+	if !localized {
+		if prediction := surname(); prediction != nil {
+			enrichment = withNationality(enrichment, mergeNationality(enrichment.Nationality, prediction, e.cfg.SurnameWeight))
+		}
+	}
+
+	return enrichment, nil
+}
+
+func (e *Enricher) localize(ctx context.Context, person *entity.Person, enrichment *entity.Enrichment, surname func() *entity.NationalityPrediction) (string, bool, error) {
+	if person.CountryHint != "" {
+		return person.CountryHint, false, nil
+	}
+	if !e.cfg.CountryFromNationality {
+		return e.cfg.DefaultCountry, false, nil
+	}
+
+	nationality, err := e.client.GetNationality(ctx, person.CanonicalName)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return "", false, ctxErr
+	}
+	if err != nil {
+		nationality = nil
+	}
+
+	nationality = mergeNationality(nationality, surname(), e.cfg.SurnameWeight)
+	if nationality == nil {
+		enrichment.SetError(entity.FieldNationality, err)
+		return e.cfg.DefaultCountry, true, nil
+	}
+
+	enrichment.Nationality = nationality
+	return nationality.Top(), true, nil
+}
+
+func (e *Enricher) fetchRemaining(ctx context.Context, name, country string, enrichment *entity.Enrichment, withNationality bool) error {
+	var (
+		gender    *entity.GenderPrediction
+		ageErr    error
+		genderErr error
+		nationErr error
+		wg        sync.WaitGroup
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		enrichment.Age, ageErr = e.client.GetAge(ctx, name, country)
+	}()
+
+	if enrichment.Gender == nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gender, genderErr = e.client.GetGender(ctx, name, country)
+		}()
+	}
+
+	if withNationality {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			enrichment.Nationality, nationErr = e.client.GetNationality(ctx, name)
+		}()
+	}
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if gender != nil {
+		enrichment.Gender = gender
+	}
+
+	if ageErr != nil {
+		enrichment.SetError(entity.FieldAge, ageErr)
+	}
+	if genderErr != nil {
+		enrichment.SetError(entity.FieldGender, genderErr)
+	}
+	if nationErr != nil {
+		enrichment.SetError(entity.FieldNationality, nationErr)
+	}
+
+	return nil
+}
+
+func (e *Enricher) enrichPeople(ctx context.Context, people []*entity.Person) []*entity.Enrichment {
+	for _, person := range people {
+		e.canonicalize(person)
+	}
+
+	var (
+		surnames    map[string]*entity.NationalityPrediction
+		prefetching sync.WaitGroup
+	)
+
+	prefetching.Add(1)
+	go func() {
+		defer prefetching.Done()
+		surnames = e.prefetchSurnames(ctx, people)
+	}()
+
+	nationalities := e.prefetchNationalities(ctx, people)
+	prefetching.Wait()
+
+	groups := make(map[batchGroup][]string)
+	keys := make([]batchGroup, len(people))
+	for i, person := range people {
+		country := person.CountryHint
+		if country == "" {
+			country = e.cfg.DefaultCountry
+			if e.cfg.CountryFromNationality {
+				if nationality := mergeNationality(nationalities[person.CanonicalName], surnames[e.surnameKey(person)], e.cfg.SurnameWeight); nationality != nil {
+					country = nationality.Top()
+				}
+			}
+		}
+		person.LocalizationCountry = country
+
+		keys[i] = batchGroup{
+			country:    country,
+			rulesFirst: e.cfg.GenderRules == GenderRulesFirst && inferGender(person.Surname, person.Patronymic, country) != nil,
+		}
+		groups[keys[i]] = append(groups[keys[i]], person.CanonicalName)
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		enriched = make(map[batchGroup]map[string]*entity.Enrichment, len(groups))
+	)
+
+	for group, names := range groups {
+		var skip []string
+		if group.rulesFirst {
+			skip = append(skip, entity.FieldGender)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			results := e.client.EnrichPeople(ctx, names, group.country, skip...)
+
+			mu.Lock()
+			defer mu.Unlock()
+			enriched[group] = results
+		}()
+	}
+
+	wg.Wait()
+
+	results := make([]*entity.Enrichment, len(people))
+	for i, person := range people {
+		enrichment, ok := enriched[keys[i]][person.CanonicalName]
+		if !ok {
+			continue
+		}
+
+		nationality := enrichment.Nationality
+		if prefetched, ok := nationalities[person.CanonicalName]; ok && person.CountryHint == "" {
+			nationality = prefetched
+		}
+		if merged := mergeNationality(nationality, surnames[e.surnameKey(person)], e.cfg.SurnameWeight); merged != enrichment.Nationality {
+			enrichment = withNationality(enrichment, merged)
+		}
+
+		if gender := e.rulesGender(person, enrichment); gender != nil {
+			enrichment = withGender(enrichment, gender)
+		} else {
+			copied := *enrichment
+			enrichment = &copied
+		}
+
+		results[i] = enrichment
+	}
+
+	e.fallbackGenders(ctx, people, results)
+
+	for _, enrichment := range results {
+		if enrichment != nil {
+			e.cfg.Thresholds.Apply(enrichment)
+		}
+	}
+
+	return results
+}
+
+func (e *Enricher) fallbackGenders(ctx context.Context, people []*entity.Person, results []*entity.Enrichment) {
+	if e.cfg.GenderFallback == nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i, person := range people {
+		if results[i] == nil || results[i].Gender != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = e.fallbackGender(ctx, person.CanonicalName, person.LocalizationCountry, results[i])
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (e *Enricher) fallbackGender(ctx context.Context, name, country string, enrichment *entity.Enrichment) *entity.Enrichment {
+	if enrichment.Gender != nil || e.cfg.GenderFallback == nil {
+		return enrichment
+	}
+
+	gender, err := e.cfg.GenderFallback.GetGender(ctx, name, country)
+	if err == nil {
+		return withGender(enrichment, gender)
+	}
+	if errors.Is(err, entity.ErrNoPrediction) {
+		return enrichment
+	}
+
+	result := withGender(enrichment, nil)
+	result.SetError(entity.FieldGender, err)
+	return result
+}
+
+type batchGroup struct {
+	country    string
+	rulesFirst bool
+}
+
+func (e *Enricher) prefetchNationalities(ctx context.Context, people []*entity.Person) map[string]*entity.NationalityPrediction {
+	nationalities := make(map[string]*entity.NationalityPrediction)
+	if !e.cfg.CountryFromNationality {
+		return nationalities
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	seen := make(map[string]struct{})
+	for _, person := range people {
+		if person.CountryHint != "" {
+			continue
+		}
+		if _, ok := seen[person.CanonicalName]; ok {
+			continue
+		}
+		seen[person.CanonicalName] = struct{}{}
+
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+
+			nationality, err := e.client.GetNationality(ctx, name)
+			if err != nil {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			nationalities[name] = nationality
+		}(person.CanonicalName)
+	}
+
+	wg.Wait()
+	return nationalities
+}
+
+func (e *Enricher) saveLog(ctx context.Context, recorder *entity.CallRecorder, person *entity.Person) error {
+	entries := recorder.EntriesFor(person.CanonicalName, e.surnameKey(person))
+	for i := range entries {
+		entries[i].PersonID = person.ID
+	}
+
+	if err := e.logs.Save(ctx, entries); err != nil {
+		return fmt.Errorf("failed to save enrichment log for person ID=%d: %w", person.ID, err)
+	}
+
+	return nil
+}
+
+func (e *Enricher) history(ctx context.Context, personID int64, limit int) ([]*entity.EnrichmentLogEntry, error) {
+	return e.logs.ListByPersonID(ctx, personID, limit)
+}
+
+func (e *Enricher) rulesGender(person *entity.Person, enrichment *entity.Enrichment) *entity.GenderPrediction {
+	switch e.cfg.GenderRules {
+	case GenderRulesFirst:
+		return inferGender(person.Surname, person.Patronymic, person.LocalizationCountry)
+	case GenderRulesFallback:
+		if enrichment.Gender == nil {
+			return inferGender(person.Surname, person.Patronymic, person.LocalizationCountry)
+		}
+	}
+
+	return nil
+}
+
+func withGender(enrichment *entity.Enrichment, gender *entity.GenderPrediction) *entity.Enrichment {
+	result := *enrichment
+	result.Gender = gender
+	result.Errors = nil
+
+	for field, err := range enrichment.Errors {
+		if field != entity.FieldGender {
+			result.SetError(field, err)
+		}
+	}
+
+	return &result
+}
+
+func withNationality(enrichment *entity.Enrichment, nationality *entity.NationalityPrediction) *entity.Enrichment {
+	result := *enrichment
+	result.Nationality = nationality
+	result.Errors = nil
+
+	for field, err := range enrichment.Errors {
+		if field != entity.FieldNationality {
+			result.SetError(field, err)
+		}
+	}
+
+	return &result
+}
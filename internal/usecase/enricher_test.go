@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"Name_IQ_Finder/internal/entity"
+	"Name_IQ_Finder/internal/normalize"
+)
+
+type batchCall struct {
+	names []string
+	skip  []string
+}
+
+type fakeClient struct {
+	mu    sync.Mutex
+	calls []batchCall
+}
+
+func (c *fakeClient) GetAge(ctx context.Context, name, countryID string) (*entity.AgePrediction, error) {
+	return &entity.AgePrediction{Age: 40, Count: 100}, nil
+}
+
+func (c *fakeClient) GetGender(ctx context.Context, name, countryID string) (*entity.GenderPrediction, error) {
+	return &entity.GenderPrediction{Gender: genderFemale, Probability: 0.9, Count: 100}, nil
+}
+
+func (c *fakeClient) GetNationality(ctx context.Context, name string) (*entity.NationalityPrediction, error) {
+	return &entity.NationalityPrediction{Countries: []entity.CountryProbability{{CountryID: "RU", Probability: 0.8}}, Count: 100}, nil
+}
+
+func (c *fakeClient) EnrichPerson(ctx context.Context, name, countryID string) (*entity.Enrichment, error) {
+	results := c.EnrichPeople(ctx, []string{name}, countryID)
+	return results[name], nil
+}
+
+func (c *fakeClient) EnrichPeople(ctx context.Context, names []string, countryID string, skip ...string) map[string]*entity.Enrichment {
+	c.mu.Lock()
+	c.calls = append(c.calls, batchCall{names: slices.Clone(names), skip: skip})
+	c.mu.Unlock()
+
+	results := make(map[string]*entity.Enrichment, len(names))
+	for _, name := range names {
+		enrichment := &entity.Enrichment{}
+		enrichment.Age, _ = c.GetAge(ctx, name, countryID)
+		enrichment.Gender, _ = c.GetGender(ctx, name, countryID)
+		enrichment.Nationality, _ = c.GetNationality(ctx, name)
+		results[name] = enrichment.Without(skip...)
+	}
+
+	return results
+}
+
+type noDiminutives struct{}
+
+func (noDiminutives) Resolve(name string) (string, bool) {
+	return "", false
+}
+
+func newTestEnricher(t *testing.T, client entity.ExternalAPIClient, rules string) *Enricher {
+	t.Helper()
+
+	normalizer, err := normalize.New(normalize.SchemeNone)
+	if err != nil {
+		t.Fatalf("normalize.New() error = %v", err)
+	}
+
+	return NewEnricher(client, nil, normalizer, noDiminutives{}, nil, EnricherConfig{GenderRules: rules})
+}
+
+func TestEnrichPeopleSkipsGenderResolvedByRules(t *testing.T) {
+	tests := []struct {
+		name           string
+		rules          string
+		wantGenderized []string
+		wantGenders    []string
+	}{
+		{
+			name:           "rules first",
+			rules:          GenderRulesFirst,
+			wantGenderized: []string{"alex"},
+			wantGenders:    []string{genderMale, genderFemale, genderFemale},
+		},
+		{
+			name:           "rules fallback",
+			rules:          GenderRulesFallback,
+			wantGenderized: []string{"иван", "alex", "мария"},
+			wantGenders:    []string{genderFemale, genderFemale, genderFemale},
+		},
+		{
+			name:           "rules off",
+			rules:          GenderRulesOff,
+			wantGenderized: []string{"иван", "alex", "мария"},
+			wantGenders:    []string{genderFemale, genderFemale, genderFemale},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeClient{}
+			enricher := newTestEnricher(t, client, tt.rules)
+
+			people := []*entity.Person{
+				{Name: "Иван", Surname: "Петров", Patronymic: "Иванович"},
+				{Name: "Alex", Surname: "Smith"},
+				{Name: "Мария", Surname: "Иванова"},
+			}
+			results := enricher.enrichPeople(context.Background(), people)
+
+			var genderized []string
+			for _, call := range client.calls {
+				if !slices.Contains(call.skip, entity.FieldGender) {
+					genderized = append(genderized, call.names...)
+				}
+			}
+			slices.Sort(genderized)
+			want := slices.Clone(tt.wantGenderized)
+			slices.Sort(want)
+			if !slices.Equal(genderized, want) {
+				t.Errorf("genderized names = %v, want %v", genderized, want)
+			}
+
+			for i, result := range results {
+				if result == nil || result.Gender == nil {
+					t.Fatalf("result[%d] has no gender", i)
+				}
+				if result.Gender.Gender != tt.wantGenders[i] {
+					t.Errorf("result[%d] gender = %s, want %s", i, result.Gender.Gender, tt.wantGenders[i])
+				}
+				if result.Age == nil || result.Nationality == nil {
+					t.Errorf("result[%d] lost age or nationality", i)
+				}
+			}
+		})
+	}
+}
+
+type genderlessClient struct {
+	fakeClient
+}
+
+func (c *genderlessClient) EnrichPeople(ctx context.Context, names []string, countryID string, skip ...string) map[string]*entity.Enrichment {
+	results := c.fakeClient.EnrichPeople(ctx, names, countryID, skip...)
+	for name, enrichment := range results {
+		results[name] = withGender(enrichment, nil)
+		results[name].SetError(entity.FieldGender, entity.ErrNoPrediction)
+	}
+
+	return results
+}
+
+type fakeGenderPredictor struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (p *fakeGenderPredictor) GetGender(ctx context.Context, name, countryID string) (*entity.GenderPrediction, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.names = append(p.names, name)
+	return &entity.GenderPrediction{Gender: genderFemale, Probability: 0.9, Count: 10, Source: entity.SourceDataset}, nil
+}
+
+func TestEnrichPeopleFallsBackAfterRules(t *testing.T) {
+	fallback := &fakeGenderPredictor{}
+	enricher := newTestEnricher(t, &genderlessClient{}, GenderRulesFallback)
+	enricher.cfg.GenderFallback = fallback
+
+	people := []*entity.Person{
+		{Name: "Иван", Surname: "Петров", Patronymic: "Иванович"},
+		{Name: "Alex", Surname: "Smith"},
+	}
+	results := enricher.enrichPeople(context.Background(), people)
+
+	if !slices.Equal(fallback.names, []string{"alex"}) {
+		t.Errorf("fallback names = %v, want [alex]", fallback.names)
+	}
+
+	wantSources := []string{entity.SourceRules, entity.SourceDataset}
+	for i, result := range results {
+		if result == nil || result.Gender == nil {
+			t.Fatalf("result[%d] has no gender", i)
+		}
+		if result.Gender.Source != wantSources[i] {
+			t.Errorf("result[%d] gender source = %s, want %s", i, result.Gender.Source, wantSources[i])
+		}
+		if _, ok := result.Errors[entity.FieldGender]; ok {
+			t.Errorf("result[%d] kept a gender error", i)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+type WorkerConfig struct {
+	Workers       int
+	PollInterval  time.Duration
+	MaxAttempts   int
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	JobTimeout    time.Duration
+}
+
+type EnrichmentWorker struct {
+	repo     entity.PersonRepository
+	jobs     entity.EnrichmentJobRepository
+	enricher *Enricher
+	cfg      WorkerConfig
+	logger   *log.Logger
+}
+
+func NewEnrichmentWorker(repo entity.PersonRepository, jobs entity.EnrichmentJobRepository, enricher *Enricher, cfg WorkerConfig, logger *log.Logger) *EnrichmentWorker {
+	return &EnrichmentWorker{
+		repo:     repo,
+		jobs:     jobs,
+		enricher: enricher,
+		cfg:      cfg,
+		logger:   logger,
+	}
+}
+
+func (w *EnrichmentWorker) Run(ctx context.Context) {
+	w.logger.Printf("Starting %d enrichment workers", w.cfg.Workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx)
+		}()
+	}
+
+	wg.Wait()
+	w.logger.Printf("Enrichment workers stopped")
+}
+
+func (w *EnrichmentWorker) loop(ctx context.Context) {
+	for {
+		job, err := w.jobs.Claim(ctx, 2*w.cfg.JobTimeout, w.cfg.MaxAttempts)
+		if err != nil && ctx.Err() == nil {
+			w.logger.Printf("Error claiming enrichment job: %v", err)
+		}
+
+		if job != nil && job.Status == entity.JobDead {
+			w.logger.Printf("Enrichment job ID=%d for person ID=%d buried after its lease expired on attempt %d", job.ID, job.PersonID, job.Attempts)
+			w.markFailed(ctx, job.PersonID)
+			continue
+		}
+
+		if job != nil {
+			w.process(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.cfg.PollInterval):
+		}
+	}
+}
+
+func (w *EnrichmentWorker) process(ctx context.Context, job *entity.EnrichmentJob) {
+	jobCtx, cancel := context.WithTimeout(ctx, w.cfg.JobTimeout)
+	defer cancel()
+
+	err := w.enrich(jobCtx, job.PersonID)
+	if err == nil {
+		if err := w.jobs.Complete(ctx, job.ID); err != nil {
+			w.logger.Printf("Error completing enrichment job ID=%d: %v", job.ID, err)
+		}
+		return
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	if job.Attempts >= w.cfg.MaxAttempts {
+		w.logger.Printf("Enrichment job ID=%d for person ID=%d failed permanently after %d attempts: %v", job.ID, job.PersonID, job.Attempts, err)
+		if err := w.jobs.Bury(ctx, job.ID, err.Error()); err != nil {
+			w.logger.Printf("Error burying enrichment job ID=%d: %v", job.ID, err)
+		}
+		w.markFailed(ctx, job.PersonID)
+		return
+	}
+
+	runAt := time.Now().Add(w.retryDelay(job.Attempts))
+
+	var quotaErr *entity.QuotaError
+	if errors.As(err, &quotaErr) && quotaErr.ResetAt.After(runAt) {
+		runAt = quotaErr.ResetAt
+	}
+	w.logger.Printf("Enrichment job ID=%d for person ID=%d failed (attempt %d), retrying at %s: %v", job.ID, job.PersonID, job.Attempts, runAt.Format(time.RFC3339), err)
+	if err := w.jobs.Retry(ctx, job.ID, err.Error(), runAt); err != nil {
+		w.logger.Printf("Error rescheduling enrichment job ID=%d: %v", job.ID, err)
+	}
+}
+
+func (w *EnrichmentWorker) retryDelay(attempts int) time.Duration {
+	delay := w.cfg.RetryDelay
+	for i := 1; i < attempts; i++ {
+		if w.cfg.MaxRetryDelay > 0 && delay >= w.cfg.MaxRetryDelay {
+			break
+		}
+		if delay > math.MaxInt64/2 {
+			return math.MaxInt64
+		}
+		delay *= 2
+	}
+
+	if w.cfg.MaxRetryDelay > 0 && delay > w.cfg.MaxRetryDelay {
+		delay = w.cfg.MaxRetryDelay
+	}
+
+	return delay
+}
+
+func (w *EnrichmentWorker) enrich(ctx context.Context, personID int64) error {
+	person, err := w.repo.GetByID(ctx, personID)
+	if err != nil {
+		return fmt.Errorf("failed to get person: %w", err)
+	}
+
+	enrichCtx, recorder := entity.WithCallRecorder(ctx)
+
+	enrichment, err := w.enricher.enrichPerson(enrichCtx, person)
+	if err := w.enricher.saveLog(ctx, recorder, person); err != nil {
+		w.logger.Printf("Error saving enrichment log: %v", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to enrich person data: %w", err)
+	}
+
+	if !person.ApplyEnrichment(*enrichment) {
+		return fmt.Errorf("failed to enrich person data: %w", enrichment.Err())
+	}
+
+	if err := w.repo.Update(ctx, person); err != nil {
+		return fmt.Errorf("failed to update person: %w", err)
+	}
+
+	if err := enrichment.Err(); err != nil {
+		return fmt.Errorf("partial enrichment: %w", err)
+	}
+
+	w.logger.Printf("Person ID=%d enriched: status=%s, missing=%v", person.ID, person.EnrichmentStatus, person.MissingFields())
+	return nil
+}
+
+func (w *EnrichmentWorker) markFailed(ctx context.Context, personID int64) {
+	person, err := w.repo.GetByID(ctx, personID)
+	if err != nil {
+		w.logger.Printf("Error getting person ID=%d: %v", personID, err)
+		return
+	}
+
+	if person.EnrichmentStatus != entity.EnrichmentPending {
+		return
+	}
+
+	person.EnrichmentStatus = entity.EnrichmentFailed
+	if err := w.repo.Update(ctx, person); err != nil {
+		w.logger.Printf("Error marking person ID=%d as failed: %v", personID, err)
+	}
+}
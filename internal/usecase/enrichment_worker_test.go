@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEnrichmentWorkerRetryDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      WorkerConfig
+		attempts int
+		want     time.Duration
+	}{
+		{name: "first attempt", cfg: WorkerConfig{RetryDelay: 10 * time.Second, MaxRetryDelay: time.Minute}, attempts: 1, want: 10 * time.Second},
+		{name: "doubles per attempt", cfg: WorkerConfig{RetryDelay: 10 * time.Second, MaxRetryDelay: time.Minute}, attempts: 3, want: 40 * time.Second},
+		{name: "capped", cfg: WorkerConfig{RetryDelay: 10 * time.Second, MaxRetryDelay: time.Minute}, attempts: 4, want: time.Minute},
+		{name: "large attempt capped", cfg: WorkerConfig{RetryDelay: 10 * time.Second, MaxRetryDelay: time.Minute}, attempts: 100, want: time.Minute},
+		{name: "uncapped does not overflow", cfg: WorkerConfig{RetryDelay: 10 * time.Second}, attempts: 100, want: math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &EnrichmentWorker{cfg: tt.cfg}
+			if got := w.retryDelay(tt.attempts); got != tt.want {
+				t.Fatalf("retryDelay(%d) = %s, want %s", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
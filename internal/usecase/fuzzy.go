@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+func (uc *PersonUseCase) FuzzySearch(ctx context.Context, query *entity.FuzzyQuery, filter *entity.Filter, page, limit int) ([]*entity.ScoredPerson, int, error) {
+	uc.logger.Printf("Fuzzy searching persons with q=%q, filter=%v, page=%d, limit=%d", query.Text, filter, page, limit)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	words := strings.FieldsFunc(query.Text, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == ','
+	})
+	if len(words) == 0 {
+		return nil, 0, fmt.Errorf("%w: q is required for fuzzy search", entity.ErrInvalidFilter)
+	}
+	if len(words) > entity.MaxFuzzyTerms {
+		return nil, 0, fmt.Errorf("%w: fuzzy search accepts at most %d words", entity.ErrInvalidFilter, entity.MaxFuzzyTerms)
+	}
+
+	query.Terms = make([]entity.FuzzyTerm, len(words))
+	for i, word := range words {
+		query.Terms[i] = entity.FuzzyTerm{
+			Text:     word,
+			Phonetic: uc.enricher.normalizer.Phonetic(word),
+		}
+	}
+
+	results, total, err := uc.repo.FuzzySearch(ctx, query, filter, page, limit)
+	if err != nil {
+		uc.logger.Printf("Error fuzzy searching persons: %v", err)
+		return nil, 0, fmt.Errorf("failed to search persons: %w", err)
+	}
+
+	uc.logger.Printf("Found %d persons (total: %d)", len(results), total)
+	return results, total, nil
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+const (
+	genderMale   = "male"
+	genderFemale = "female"
+)
+
+type suffixRule struct {
+	suffix string
+	gender string
+}
+
+var patronymicRules = []suffixRule{
+	{"инична", genderFemale},
+	{"ична", genderFemale},
+	{"овна", genderFemale},
+	{"евна", genderFemale},
+	{"ович", genderMale},
+	{"евич", genderMale},
+	{"ич", genderMale},
+	{"inichna", genderFemale},
+	{"ichna", genderFemale},
+	{"ovna", genderFemale},
+	{"evna", genderFemale},
+	{"ovich", genderMale},
+	{"evich", genderMale},
+	{"ich", genderMale},
+}
+
+var patronymicMarkers = map[string]string{
+	"оглы": genderMale,
+	"улы":  genderMale,
+	"ogly": genderMale,
+	"oglu": genderMale,
+	"uly":  genderMale,
+	"кызы": genderFemale,
+	"гызы": genderFemale,
+	"kyzy": genderFemale,
+	"kizi": genderFemale,
+	"qizi": genderFemale,
+}
+
+var cyrillicSurnameRules = []suffixRule{
+	{"ская", genderFemale},
+	{"цкая", genderFemale},
+	{"ова", genderFemale},
+	{"ева", genderFemale},
+	{"ёва", genderFemale},
+	{"ина", genderFemale},
+	{"ына", genderFemale},
+	{"ский", genderMale},
+	{"цкий", genderMale},
+	{"ской", genderMale},
+	{"ов", genderMale},
+	{"ев", genderMale},
+	{"ёв", genderMale},
+	{"ин", genderMale},
+	{"ын", genderMale},
+}
+
+var latinSurnameRules = []suffixRule{
+	{"skaya", genderFemale},
+	{"tskaya", genderFemale},
+	{"ova", genderFemale},
+	{"eva", genderFemale},
+	{"yova", genderFemale},
+	{"skiy", genderMale},
+	{"skii", genderMale},
+	{"sky", genderMale},
+	{"skoy", genderMale},
+	{"ov", genderMale},
+	{"ev", genderMale},
+	{"yov", genderMale},
+}
+
+var cyrillicNameCountries = map[string]bool{
+	"BG": true,
+	"BY": true,
+	"KG": true,
+	"KZ": true,
+	"MK": true,
+	"RU": true,
+	"TJ": true,
+	"UA": true,
+	"UZ": true,
+}
+
+func inferGender(surname, patronymic, country string) *entity.GenderPrediction {
+	if gender := patronymicGender(patronymic); gender != "" {
+		return ruleGender(gender)
+	}
+
+	word := lastWord(surname)
+	if gender := matchSuffix(word, cyrillicSurnameRules); gender != "" {
+		return ruleGender(gender)
+	}
+	if patronymic == "" && !cyrillicNameCountries[country] {
+		return nil
+	}
+	if gender := matchSuffix(word, latinSurnameRules); gender != "" {
+		return ruleGender(gender)
+	}
+
+	return nil
+}
+
+func patronymicGender(patronymic string) string {
+	words := strings.Fields(strings.ToLower(patronymic))
+	if len(words) == 0 {
+		return ""
+	}
+
+	if gender, ok := patronymicMarkers[words[len(words)-1]]; ok {
+		return gender
+	}
+
+	return matchSuffix(words[0], patronymicRules)
+}
+
+func matchSuffix(word string, rules []suffixRule) string {
+	for _, rule := range rules {
+		if !strings.HasSuffix(word, rule.suffix) {
+			continue
+		}
+		if utf8.RuneCountInString(word)-utf8.RuneCountInString(rule.suffix) < 2 {
+			continue
+		}
+		return rule.gender
+	}
+
+	return ""
+}
+
+func lastWord(value string) string {
+	words := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return r == ' ' || r == '-'
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	return words[len(words)-1]
+}
+
+func ruleGender(gender string) *entity.GenderPrediction {
+	return &entity.GenderPrediction{
+		Gender:      gender,
+		Probability: 1,
+		Source:      entity.SourceRules,
+	}
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"testing"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+func TestInferGender(t *testing.T) {
+	tests := []struct {
+		name       string
+		surname    string
+		patronymic string
+		country    string
+		want       string
+	}{
+		{name: "male patronymic", surname: "Смит", patronymic: "Иванович", want: genderMale},
+		{name: "female patronymic", surname: "Смит", patronymic: "Ивановна", want: genderFemale},
+		{name: "female -ична patronymic", surname: "", patronymic: "Ильинична", want: genderFemale},
+		{name: "male -ич patronymic", surname: "", patronymic: "Ильич", want: genderMale},
+		{name: "latin patronymic", surname: "", patronymic: "Sergeevna", want: genderFemale},
+		{name: "patronymic case insensitive", surname: "", patronymic: "ПЕТРОВИЧ", want: genderMale},
+		{name: "patronymic wins over surname", surname: "Петрова", patronymic: "Иванович", want: genderMale},
+		{name: "turkic male marker", surname: "", patronymic: "Ильхам оглы", want: genderMale},
+		{name: "turkic female marker", surname: "", patronymic: "Ильхам кызы", want: genderFemale},
+		{name: "latin female marker", surname: "", patronymic: "Ilham qizi", want: genderFemale},
+		{name: "female surname", surname: "Петрова", want: genderFemale},
+		{name: "male surname", surname: "Петров", want: genderMale},
+		{name: "female -ская surname", surname: "Достоевская", want: genderFemale},
+		{name: "male -ский surname", surname: "Достоевский", want: genderMale},
+		{name: "ё surname", surname: "Королёва", want: genderFemale},
+		{name: "latin female surname", surname: "Ivanova", country: "RU", want: genderFemale},
+		{name: "latin male surname", surname: "Tchaikovsky", country: "RU", want: genderMale},
+		{name: "latin surname with unrecognized patronymic", surname: "Ivanova", patronymic: "Ilham", want: genderFemale},
+		{name: "latin surname without slavic context", surname: "Ivanova"},
+		{name: "italian -ova surname", surname: "Casanova", country: "IT"},
+		{name: "italian -ova surname without country", surname: "Villanova"},
+		{name: "latin -ov surname without slavic context", surname: "Zukov"},
+		{name: "cyrillic surname needs no context", surname: "Иванова", country: "IT", want: genderFemale},
+		{name: "double-barrelled surname", surname: "Смит-Петрова", want: genderFemale},
+		{name: "too short to match", surname: "Ов", patronymic: "Ич"},
+		{name: "unknown surname", surname: "Smith"},
+		{name: "nothing to infer from"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := inferGender(tt.surname, tt.patronymic, tt.country)
+			if tt.want == "" {
+				if got != nil {
+					t.Fatalf("inferGender(%q, %q) = %+v, want nil", tt.surname, tt.patronymic, got)
+				}
+				return
+			}
+
+			if got == nil {
+				t.Fatalf("inferGender(%q, %q) = nil, want %s", tt.surname, tt.patronymic, tt.want)
+			}
+			if got.Gender != tt.want {
+				t.Errorf("inferGender(%q, %q) gender = %s, want %s", tt.surname, tt.patronymic, got.Gender, tt.want)
+			}
+			if got.Probability != 1 || got.Source != entity.SourceRules {
+				t.Errorf("inferGender(%q, %q) = %+v, want a rules prediction", tt.surname, tt.patronymic, got)
+			}
+		})
+	}
+}
@@ -1,50 +1,73 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
+	"sync"
 	"time"
 
 	"Name_IQ_Finder/internal/entity"
 )
 
 type PersonUseCase struct {
-	repo   entity.PersonRepository
-	client entity.ExternalAPIClient
-	logger *log.Logger
+	repo     entity.PersonRepository
+	jobs     entity.EnrichmentJobRepository
+	enricher *Enricher
+	async    bool
+	logger   *log.Logger
+
+	mu          sync.Mutex
+	lastRefresh *entity.RefreshReport
 }
 
-func NewPersonUseCase(repo entity.PersonRepository, client entity.ExternalAPIClient, logger *log.Logger) *PersonUseCase {
+func NewPersonUseCase(repo entity.PersonRepository, jobs entity.EnrichmentJobRepository, enricher *Enricher, async bool, logger *log.Logger) *PersonUseCase {
 	return &PersonUseCase{
-		repo:   repo,
-		client: client,
-		logger: logger,
+		repo:     repo,
+		jobs:     jobs,
+		enricher: enricher,
+		async:    async,
+		logger:   logger,
 	}
 }
 
-func (uc *PersonUseCase) Create(name, surname, patronymic string) (*entity.Person, error) {
+func (uc *PersonUseCase) Create(ctx context.Context, name, surname, patronymic, countryID string) (*entity.Person, error) {
 	uc.logger.Printf("Creating person with name=%s, surname=%s", name, surname)
 
-	age, gender, nationality, err := uc.client.EnrichPerson(name)
-	if err != nil {
-		uc.logger.Printf("Error enriching person data: %v", err)
-		return nil, fmt.Errorf("failed to enrich person data: %w", err)
+	if uc.async {
+		return uc.createPending(ctx, name, surname, patronymic, countryID)
 	}
 
-	uc.logger.Printf("Enriched data: age=%d, gender=%s, nationality=%s", age, gender, nationality)
-
 	person := &entity.Person{
 		Name:        name,
 		Surname:     surname,
 		Patronymic:  patronymic,
-		Age:         age,
-		Gender:      gender,
-		Nationality: nationality,
+		CountryHint: strings.ToUpper(countryID),
 		CreatedAt:   time.Now().Format(time.RFC3339),
 		UpdatedAt:   time.Now().Format(time.RFC3339),
 	}
 
-	id, err := uc.repo.Create(person)
+	enrichCtx, recorder := entity.WithCallRecorder(ctx)
+
+	enrichment, err := uc.enricher.enrichPerson(enrichCtx, person)
+	if err != nil {
+		uc.logger.Printf("Error enriching person data: %v", err)
+		return nil, fmt.Errorf("failed to enrich person data: %w", err)
+	}
+	if quotaErr := enrichment.QuotaErr(); quotaErr != nil {
+		uc.logger.Printf("Refusing to create person with name=%s: %v", name, quotaErr)
+		return nil, fmt.Errorf("failed to enrich person data: %w", quotaErr)
+	}
+	if err := enrichment.Err(); err != nil {
+		uc.logger.Printf("Partial enrichment for name=%s: %v", name, err)
+	}
+
+	person.ApplyEnrichment(*enrichment)
+
+	uc.logger.Printf("Enriched data: status=%s, gender_source=%s, missing=%v", person.EnrichmentStatus, person.GenderSource, person.MissingFields())
+
+	id, err := uc.repo.Create(ctx, person)
 	if err != nil {
 		uc.logger.Printf("Error creating person in repository: %v", err)
 		return nil, fmt.Errorf("failed to create person: %w", err)
@@ -53,13 +76,94 @@ func (uc *PersonUseCase) Create(name, surname, patronymic string) (*entity.Perso
 	person.ID = id
 	uc.logger.Printf("Person created with ID=%d", id)
 
+	if err := uc.enricher.saveLog(ctx, recorder, person); err != nil {
+		uc.logger.Printf("Error saving enrichment log: %v", err)
+	}
+
 	return person, nil
 }
 
-func (uc *PersonUseCase) GetByID(id int64) (*entity.Person, error) {
+func (uc *PersonUseCase) createPending(ctx context.Context, name, surname, patronymic, countryID string) (*entity.Person, error) {
+	person := &entity.Person{
+		Name:             name,
+		Surname:          surname,
+		Patronymic:       patronymic,
+		CountryHint:      strings.ToUpper(countryID),
+		EnrichmentStatus: entity.EnrichmentPending,
+	}
+	uc.enricher.canonicalize(person)
+
+	id, err := uc.repo.Create(ctx, person)
+	if err != nil {
+		uc.logger.Printf("Error creating person in repository: %v", err)
+		return nil, fmt.Errorf("failed to create person: %w", err)
+	}
+	person.ID = id
+
+	if _, err := uc.jobs.Enqueue(ctx, id); err != nil {
+		uc.logger.Printf("Error enqueueing enrichment for person ID=%d: %v", id, err)
+
+		person.EnrichmentStatus = entity.EnrichmentFailed
+		if updateErr := uc.repo.Update(ctx, person); updateErr != nil {
+			uc.logger.Printf("Error marking person ID=%d as failed: %v", id, updateErr)
+		}
+		return nil, fmt.Errorf("failed to enqueue enrichment: %w", err)
+	}
+
+	uc.logger.Printf("Person created with ID=%d, enrichment pending", id)
+	return person, nil
+}
+
+func (uc *PersonUseCase) CreateBatch(ctx context.Context, people []*entity.Person) []entity.BatchCreateResult {
+	uc.logger.Printf("Creating batch of %d persons", len(people))
+
+	for _, person := range people {
+		person.CountryHint = strings.ToUpper(person.CountryHint)
+	}
+
+	enrichCtx, recorder := entity.WithCallRecorder(ctx)
+	enriched := uc.enricher.enrichPeople(enrichCtx, people)
+
+	results := make([]entity.BatchCreateResult, len(people))
+	for i, person := range people {
+		enrichment := enriched[i]
+		if enrichment == nil {
+			results[i].Err = fmt.Errorf("failed to enrich person data: no result for %q", person.Name)
+			continue
+		}
+		if quotaErr := enrichment.QuotaErr(); quotaErr != nil {
+			results[i].Err = fmt.Errorf("failed to enrich person data: %w", quotaErr)
+			continue
+		}
+		if err := enrichment.Err(); err != nil {
+			uc.logger.Printf("Partial enrichment for name=%s: %v", person.Name, err)
+		}
+
+		person.ApplyEnrichment(*enrichment)
+
+		id, err := uc.repo.Create(ctx, person)
+		if err != nil {
+			uc.logger.Printf("Error creating person in repository: %v", err)
+			results[i].Err = fmt.Errorf("failed to create person: %w", err)
+			continue
+		}
+
+		person.ID = id
+		results[i].Person = person
+
+		if err := uc.enricher.saveLog(ctx, recorder, person); err != nil {
+			uc.logger.Printf("Error saving enrichment log: %v", err)
+		}
+	}
+
+	uc.logger.Printf("Batch of %d persons processed", len(people))
+	return results
+}
+
+func (uc *PersonUseCase) GetByID(ctx context.Context, id int64) (*entity.Person, error) {
 	uc.logger.Printf("Getting person with ID=%d", id)
 
-	person, err := uc.repo.GetByID(id)
+	person, err := uc.repo.GetByID(ctx, id)
 	if err != nil {
 		uc.logger.Printf("Error getting person with ID=%d: %v", id, err)
 		return nil, fmt.Errorf("failed to get person: %w", err)
@@ -69,7 +173,27 @@ func (uc *PersonUseCase) GetByID(id int64) (*entity.Person, error) {
 	return person, nil
 }
 
-func (uc *PersonUseCase) GetAll(filter map[string]interface{}, page, limit int) ([]*entity.Person, int, error) {
+func (uc *PersonUseCase) GetEnrichmentJob(ctx context.Context, personID int64) (*entity.EnrichmentJob, error) {
+	job, err := uc.jobs.GetLatestByPersonID(ctx, personID)
+	if err != nil {
+		uc.logger.Printf("Error getting enrichment job for person ID=%d: %v", personID, err)
+		return nil, fmt.Errorf("failed to get enrichment job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (uc *PersonUseCase) GetEnrichmentHistory(ctx context.Context, personID int64, limit int) ([]*entity.EnrichmentLogEntry, error) {
+	entries, err := uc.enricher.history(ctx, personID, limit)
+	if err != nil {
+		uc.logger.Printf("Error getting enrichment history for person ID=%d: %v", personID, err)
+		return nil, fmt.Errorf("failed to get enrichment history: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (uc *PersonUseCase) GetAll(ctx context.Context, filter *entity.Filter, page, limit int) ([]*entity.Person, int, error) {
 	uc.logger.Printf("Getting persons with filter=%v, page=%d, limit=%d", filter, page, limit)
 
 	if page < 1 {
@@ -79,7 +203,7 @@ func (uc *PersonUseCase) GetAll(filter map[string]interface{}, page, limit int)
 		limit = 10
 	}
 
-	persons, total, err := uc.repo.GetAll(filter, page, limit)
+	persons, total, err := uc.repo.GetAll(ctx, filter, page, limit)
 	if err != nil {
 		uc.logger.Printf("Error getting persons: %v", err)
 		return nil, 0, fmt.Errorf("failed to get persons: %w", err)
@@ -89,10 +213,37 @@ func (uc *PersonUseCase) GetAll(filter map[string]interface{}, page, limit int)
 	return persons, total, nil
 }
 
-func (uc *PersonUseCase) Update(person *entity.Person) error {
+func (uc *PersonUseCase) Search(ctx context.Context, query *entity.SearchQuery) ([]*entity.Person, int, error) {
+	uc.logger.Printf("Searching persons with query=%v, page=%d, limit=%d", query.Where, query.Page, query.Limit)
+
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit < 1 {
+		query.Limit = 10
+	}
+
+	persons, total, err := uc.repo.Search(ctx, query)
+	if err != nil {
+		uc.logger.Printf("Error searching persons: %v", err)
+		return nil, 0, fmt.Errorf("failed to search persons: %w", err)
+	}
+
+	uc.logger.Printf("Found %d persons (total: %d)", len(persons), total)
+	return persons, total, nil
+}
+
+func (uc *PersonUseCase) Update(ctx context.Context, person *entity.Person) error {
 	uc.logger.Printf("Updating person with ID=%d", person.ID)
 
-	err := uc.repo.Update(person)
+	uc.enricher.canonicalize(person)
+	person.ClearReviewedFields()
+
+	if person.EnrichmentStatus == entity.EnrichmentPartial && len(person.MissingFields()) == 0 {
+		person.EnrichmentStatus = entity.EnrichmentEnriched
+	}
+
+	err := uc.repo.Update(ctx, person)
 	if err != nil {
 		uc.logger.Printf("Error updating person with ID=%d: %v", person.ID, err)
 		return fmt.Errorf("failed to update person: %w", err)
@@ -102,10 +253,10 @@ func (uc *PersonUseCase) Update(person *entity.Person) error {
 	return nil
 }
 
-func (uc *PersonUseCase) Delete(id int64) error {
+func (uc *PersonUseCase) Delete(ctx context.Context, id int64) error {
 	uc.logger.Printf("Deleting person with ID=%d", id)
 
-	err := uc.repo.Delete(id)
+	err := uc.repo.Delete(ctx, id)
 	if err != nil {
 		uc.logger.Printf("Error deleting person with ID=%d: %v", id, err)
 		return fmt.Errorf("failed to delete person: %w", err)
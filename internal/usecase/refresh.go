@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+func (uc *PersonUseCase) Reenrich(ctx context.Context, id int64) (*entity.Person, *entity.EnrichmentReport, error) {
+	uc.logger.Printf("Re-enriching person with ID=%d", id)
+
+	person, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		uc.logger.Printf("Error getting person with ID=%d: %v", id, err)
+		return nil, nil, fmt.Errorf("failed to get person: %w", err)
+	}
+
+	enrichCtx, recorder := entity.WithCallRecorder(ctx)
+
+	enrichment, err := uc.enricher.enrichPerson(enrichCtx, person)
+	if err != nil {
+		uc.logger.Printf("Error enriching person with ID=%d: %v", id, err)
+		return nil, nil, fmt.Errorf("failed to enrich person data: %w", err)
+	}
+
+	report, err := uc.applyAndSave(ctx, person, enrichment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := uc.enricher.saveLog(ctx, recorder, person); err != nil {
+		uc.logger.Printf("Error saving enrichment log: %v", err)
+	}
+
+	uc.logger.Printf("Person with ID=%d re-enriched, %d fields changed", id, len(report.Changes))
+	return person, report, nil
+}
+
+func (uc *PersonUseCase) ReenrichMany(ctx context.Context, filter *entity.Filter, limit int) (*entity.RefreshReport, error) {
+	uc.logger.Printf("Re-enriching persons with filter=%v, limit=%d", filter, limit)
+
+	persons, _, err := uc.repo.GetAll(ctx, filter, 1, limit)
+	if err != nil {
+		uc.logger.Printf("Error getting persons: %v", err)
+		return nil, fmt.Errorf("failed to get persons: %w", err)
+	}
+
+	return uc.refresh(ctx, persons), nil
+}
+
+func (uc *PersonUseCase) RefreshStale(ctx context.Context, maxAge time.Duration, limit int) (*entity.RefreshReport, error) {
+	persons, err := uc.repo.GetStale(ctx, time.Now().Add(-maxAge), limit)
+	if err != nil {
+		uc.logger.Printf("Error getting stale persons: %v", err)
+		return nil, fmt.Errorf("failed to get stale persons: %w", err)
+	}
+
+	report := uc.refresh(ctx, persons)
+
+	uc.mu.Lock()
+	uc.lastRefresh = report
+	uc.mu.Unlock()
+
+	return report, nil
+}
+
+func (uc *PersonUseCase) LastRefreshReport() *entity.RefreshReport {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	return uc.lastRefresh
+}
+
+func (uc *PersonUseCase) refresh(ctx context.Context, persons []*entity.Person) *entity.RefreshReport {
+	report := &entity.RefreshReport{
+		StartedAt: time.Now(),
+		Persons:   make([]entity.EnrichmentReport, 0, len(persons)),
+	}
+
+	enrichCtx, recorder := entity.WithCallRecorder(ctx)
+	enriched := uc.enricher.enrichPeople(enrichCtx, persons)
+
+	for i, person := range persons {
+		report.Processed++
+
+		enrichment := enriched[i]
+		if enrichment == nil {
+			report.Failed++
+			report.Persons = append(report.Persons, entity.EnrichmentReport{
+				PersonID: person.ID,
+				Error:    fmt.Sprintf("no result for %q", person.Name),
+			})
+			continue
+		}
+
+		personReport, err := uc.applyAndSave(ctx, person, enrichment)
+		if err != nil {
+			report.Failed++
+			report.Persons = append(report.Persons, entity.EnrichmentReport{
+				PersonID: person.ID,
+				Error:    err.Error(),
+			})
+			continue
+		}
+
+		if err := uc.enricher.saveLog(ctx, recorder, person); err != nil {
+			uc.logger.Printf("Error saving enrichment log: %v", err)
+		}
+
+		if len(personReport.Changes) > 0 {
+			report.Changed++
+		}
+		report.Persons = append(report.Persons, *personReport)
+	}
+
+	report.FinishedAt = time.Now()
+	uc.logger.Printf("Refreshed %d persons: %d changed, %d failed", report.Processed, report.Changed, report.Failed)
+
+	return report
+}
+
+func (uc *PersonUseCase) applyAndSave(ctx context.Context, person *entity.Person, enrichment *entity.Enrichment) (*entity.EnrichmentReport, error) {
+	before := *person
+	if !person.ApplyEnrichment(*enrichment) {
+		*person = before
+		uc.logger.Printf("No fields updated for person with ID=%d: %v", person.ID, enrichment.Err())
+		return nil, fmt.Errorf("failed to enrich person data: %w", enrichment.Err())
+	}
+
+	if err := uc.repo.Update(ctx, person); err != nil {
+		uc.logger.Printf("Error updating person with ID=%d: %v", person.ID, err)
+		return nil, fmt.Errorf("failed to update person: %w", err)
+	}
+
+	report := &entity.EnrichmentReport{
+		PersonID:      person.ID,
+		Changes:       entity.DiffPersons(&before, person),
+		MissingFields: person.MissingFields(),
+	}
+	if err := enrichment.Err(); err != nil {
+		report.Error = err.Error()
+	}
+
+	return report, nil
+}
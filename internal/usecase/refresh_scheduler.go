@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+type RefreshScheduler struct {
+	useCase   entity.PersonUseCase
+	interval  time.Duration
+	maxAge    time.Duration
+	batchSize int
+	logger    *log.Logger
+}
+
+func NewRefreshScheduler(useCase entity.PersonUseCase, interval, maxAge time.Duration, batchSize int, logger *log.Logger) *RefreshScheduler {
+	return &RefreshScheduler{
+		useCase:   useCase,
+		interval:  interval,
+		maxAge:    maxAge,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+func (s *RefreshScheduler) Run(ctx context.Context) {
+	s.logger.Printf("Starting refresh scheduler (interval=%s, max age=%s)", s.interval, s.maxAge)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Printf("Refresh scheduler stopped")
+			return
+		case <-ticker.C:
+			report, err := s.useCase.RefreshStale(ctx, s.maxAge, s.batchSize)
+			if err != nil {
+				s.logger.Printf("Scheduled refresh failed: %v", err)
+				continue
+			}
+
+			for _, person := range report.Persons {
+				for _, change := range person.Changes {
+					s.logger.Printf("Person ID=%d: %s changed from %v to %v", person.PersonID, change.Field, display(change.Old), display(change.New))
+				}
+			}
+		}
+	}
+}
+
+func display(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *int:
+		if v != nil {
+			return *v
+		}
+	case *string:
+		if v != nil {
+			return *v
+		}
+	default:
+		return v
+	}
+	return nil
+}
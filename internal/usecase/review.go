@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+func (uc *PersonUseCase) GetReviewQueue(ctx context.Context, page, limit int) ([]*entity.Person, int, error) {
+	return uc.GetAll(ctx, entity.NewFilter().Eq(entity.FilterNeedsReview, true), page, limit)
+}
+
+func (uc *PersonUseCase) ResolveReview(ctx context.Context, id int64, field, action, value string) (*entity.Person, error) {
+	uc.logger.Printf("Resolving review of %s for person ID=%d: action=%s", field, id, action)
+
+	person, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		uc.logger.Printf("Error getting person with ID=%d: %v", id, err)
+		return nil, fmt.Errorf("failed to get person: %w", err)
+	}
+
+	switch action {
+	case entity.ReviewAccept:
+		err = person.AcceptReview(field)
+	case entity.ReviewOverride:
+		err = person.OverrideReview(field, value)
+	default:
+		err = fmt.Errorf("%w: unknown action %q", entity.ErrInvalidReviewValue, action)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve review: %w", err)
+	}
+
+	if err := uc.repo.Update(ctx, person); err != nil {
+		uc.logger.Printf("Error updating person with ID=%d: %v", id, err)
+		return nil, fmt.Errorf("failed to update person: %w", err)
+	}
+
+	uc.logger.Printf("Review of %s for person ID=%d resolved, needs_review=%t", field, id, person.NeedsReview)
+	return person, nil
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+func (uc *PersonUseCase) Suggest(ctx context.Context, field entity.FilterField, prefix string, limit int) ([]*entity.Suggestion, error) {
+	if !entity.SuggestFields[field] {
+		return nil, fmt.Errorf("%w: field %q must be name, surname or patronymic", entity.ErrInvalidFilter, field)
+	}
+
+	prefix = strings.TrimSpace(prefix)
+	if length := len([]rune(prefix)); length < entity.MinSuggestPrefix || length > entity.MaxSuggestPrefix {
+		return nil, fmt.Errorf("%w: prefix must be %d to %d characters", entity.ErrInvalidFilter, entity.MinSuggestPrefix, entity.MaxSuggestPrefix)
+	}
+
+	if limit < 1 {
+		limit = entity.DefaultSuggestLimit
+	}
+	if limit > entity.MaxSuggestLimit {
+		limit = entity.MaxSuggestLimit
+	}
+
+	suggestions, err := uc.repo.Suggest(ctx, field, prefix, limit)
+	if err != nil {
+		uc.logger.Printf("Error suggesting %s for prefix=%q: %v", field, prefix, err)
+		return nil, fmt.Errorf("failed to get suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
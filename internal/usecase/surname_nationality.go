@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"Name_IQ_Finder/internal/entity"
+)
+
+func (e *Enricher) surnameKey(person *entity.Person) string {
+	if e.surnames == nil || person.Surname == "" {
+		return ""
+	}
+
+	return e.normalizer.Normalize(lastWord(person.Surname))
+}
+
+func (e *Enricher) lookupSurname(ctx context.Context, person *entity.Person) func() *entity.NationalityPrediction {
+	key := e.surnameKey(person)
+	if key == "" {
+		return func() *entity.NationalityPrediction { return nil }
+	}
+
+	var (
+		prediction *entity.NationalityPrediction
+		wg         sync.WaitGroup
+	)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		result, err := e.surnames.GetNationality(ctx, key)
+		if err == nil {
+			prediction = result
+		}
+	}()
+
+	return func() *entity.NationalityPrediction {
+		wg.Wait()
+		return prediction
+	}
+}
+
+func (e *Enricher) prefetchSurnames(ctx context.Context, people []*entity.Person) map[string]*entity.NationalityPrediction {
+	surnames := make(map[string]*entity.NationalityPrediction)
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for _, person := range people {
+		key := e.surnameKey(person)
+		if key == "" {
+			continue
+		}
+		if _, ok := surnames[key]; ok {
+			continue
+		}
+		surnames[key] = nil
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			prediction, err := e.surnames.GetNationality(ctx, key)
+			if err != nil {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			surnames[key] = prediction
+		}()
+	}
+
+	wg.Wait()
+	return surnames
+}
+
+func mergeNationality(name, surname *entity.NationalityPrediction, surnameWeight float64) *entity.NationalityPrediction {
+	if surname == nil {
+		return name
+	}
+	if name == nil {
+		return &entity.NationalityPrediction{
+			Countries:        surname.Countries,
+			SurnameCountries: surname.Countries,
+			Count:            surname.Count,
+			Source:           entity.SourceSurname,
+		}
+	}
+
+	surnameWeight = min(max(surnameWeight, 0), 1)
+	nameWeight := 1 - surnameWeight
+
+	scores := make(map[string]float64)
+	for _, country := range name.Countries {
+		scores[country.CountryID] += nameWeight * country.Probability
+	}
+	for _, country := range surname.Countries {
+		scores[country.CountryID] += surnameWeight * country.Probability
+	}
+
+	result := &entity.NationalityPrediction{
+		Countries:        make([]entity.CountryProbability, 0, len(scores)),
+		NameCountries:    name.Countries,
+		SurnameCountries: surname.Countries,
+		Count:            name.Count + surname.Count,
+		Source:           entity.SourceCombined,
+	}
+	for country, score := range scores {
+		result.Countries = append(result.Countries, entity.CountryProbability{
+			CountryID:   country,
+			Probability: score,
+		})
+	}
+	sort.Slice(result.Countries, func(i, j int) bool {
+		if result.Countries[i].Probability != result.Countries[j].Probability {
+			return result.Countries[i].Probability > result.Countries[j].Probability
+		}
+		return result.Countries[i].CountryID < result.Countries[j].CountryID
+	})
+
+	return result
+}
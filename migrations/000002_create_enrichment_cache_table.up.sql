@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS enrichment_cache (
+    provider VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (provider, name)
+);
+
+CREATE INDEX idx_enrichment_cache_name ON enrichment_cache(name);
+CREATE INDEX idx_enrichment_cache_expires_at ON enrichment_cache(expires_at);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_persons_countries;
+DROP INDEX IF EXISTS idx_persons_gender_probability;
+
+ALTER TABLE persons
+    DROP COLUMN IF EXISTS countries,
+    DROP COLUMN IF EXISTS gender_count,
+    DROP COLUMN IF EXISTS gender_probability,
+    DROP COLUMN IF EXISTS age_count;
\ No newline at end of file
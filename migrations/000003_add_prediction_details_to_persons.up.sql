@@ -0,0 +1,8 @@
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS age_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS gender_probability DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS gender_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS countries JSONB NOT NULL DEFAULT '[]';
+
+CREATE INDEX idx_persons_gender_probability ON persons(gender_probability);
+CREATE INDEX idx_persons_countries ON persons USING GIN (countries jsonb_path_ops);
@@ -0,0 +1,18 @@
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS enrichment_status VARCHAR(20) NOT NULL DEFAULT 'enriched';
+
+CREATE TABLE IF NOT EXISTS enrichment_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    person_id INT NOT NULL REFERENCES persons(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_enrichment_jobs_person_id ON enrichment_jobs(person_id);
+CREATE INDEX idx_enrichment_jobs_pending ON enrichment_jobs(run_at) WHERE status = 'pending';
+CREATE INDEX idx_enrichment_jobs_running ON enrichment_jobs(locked_until) WHERE status = 'running';
@@ -0,0 +1,8 @@
+UPDATE persons SET age = 0 WHERE age IS NULL;
+UPDATE persons SET gender = '' WHERE gender IS NULL;
+UPDATE persons SET nationality = 'unknown' WHERE nationality IS NULL;
+
+ALTER TABLE persons
+    ALTER COLUMN age SET NOT NULL,
+    ALTER COLUMN gender SET NOT NULL,
+    ALTER COLUMN nationality SET NOT NULL;
\ No newline at end of file
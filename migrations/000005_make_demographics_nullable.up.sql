@@ -0,0 +1,8 @@
+ALTER TABLE persons
+    ALTER COLUMN age DROP NOT NULL,
+    ALTER COLUMN gender DROP NOT NULL,
+    ALTER COLUMN nationality DROP NOT NULL;
+
+UPDATE persons SET age = NULL WHERE age = 0 AND age_count = 0;
+UPDATE persons SET gender = NULL WHERE gender = '';
+UPDATE persons SET nationality = NULL WHERE nationality IN ('', 'unknown');
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS enriched_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE persons SET enriched_at = updated_at WHERE enrichment_status IN ('enriched', 'partial');
+
+CREATE INDEX idx_persons_enriched_at ON persons(enriched_at NULLS FIRST);
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS gender_source VARCHAR(20) NOT NULL DEFAULT '';
+
+UPDATE persons SET gender_source = 'provider' WHERE gender IS NOT NULL;
\ No newline at end of file
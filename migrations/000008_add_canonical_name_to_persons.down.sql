@@ -0,0 +1,2 @@
+ALTER TABLE persons
+    DROP COLUMN IF EXISTS canonical_name;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS canonical_name VARCHAR(255) NOT NULL DEFAULT '';
+
+UPDATE persons SET canonical_name = LOWER(TRIM(name));
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE persons
+    DROP COLUMN IF EXISTS resolved_name;
+
+DROP TABLE IF EXISTS name_diminutives;
\ No newline at end of file
@@ -0,0 +1,84 @@
+CREATE TABLE IF NOT EXISTS name_diminutives (
+    diminutive VARCHAR(100) PRIMARY KEY,
+    canonical VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO name_diminutives (diminutive, canonical) VALUES
+    ('саша', 'Александр'),
+    ('шура', 'Александр'),
+    ('лёша', 'Алексей'),
+    ('леша', 'Алексей'),
+    ('алёша', 'Алексей'),
+    ('алеша', 'Алексей'),
+    ('дима', 'Дмитрий'),
+    ('митя', 'Дмитрий'),
+    ('катя', 'Екатерина'),
+    ('настя', 'Анастасия'),
+    ('серёжа', 'Сергей'),
+    ('сережа', 'Сергей'),
+    ('женя', 'Евгений'),
+    ('коля', 'Николай'),
+    ('миша', 'Михаил'),
+    ('маша', 'Мария'),
+    ('таня', 'Татьяна'),
+    ('наташа', 'Наталья'),
+    ('оля', 'Ольга'),
+    ('лена', 'Елена'),
+    ('юля', 'Юлия'),
+    ('ира', 'Ирина'),
+    ('света', 'Светлана'),
+    ('вова', 'Владимир'),
+    ('володя', 'Владимир'),
+    ('паша', 'Павел'),
+    ('петя', 'Пётр'),
+    ('ваня', 'Иван'),
+    ('андрюша', 'Андрей'),
+    ('костя', 'Константин'),
+    ('толя', 'Анатолий'),
+    ('витя', 'Виктор'),
+    ('слава', 'Вячеслав'),
+    ('галя', 'Галина'),
+    ('люба', 'Любовь'),
+    ('надя', 'Надежда'),
+    ('аня', 'Анна'),
+    ('даша', 'Дарья'),
+    ('ксюша', 'Ксения'),
+    ('гоша', 'Георгий'),
+    ('жора', 'Георгий'),
+    ('федя', 'Фёдор'),
+    ('гриша', 'Григорий'),
+    ('стёпа', 'Степан'),
+    ('степа', 'Степан'),
+    ('тёма', 'Артём'),
+    ('тема', 'Артём'),
+    ('вася', 'Василий'),
+    ('рома', 'Роман'),
+    ('макс', 'Максим'),
+    ('кирюша', 'Кирилл'),
+    ('лиза', 'Елизавета'),
+    ('соня', 'Софья'),
+    ('вика', 'Виктория'),
+    ('katya', 'Ekaterina'),
+    ('tanya', 'Tatyana'),
+    ('kolya', 'Nikolay'),
+    ('olya', 'Olga'),
+    ('vanya', 'Ivan'),
+    ('petya', 'Petr'),
+    ('fedya', 'Fedor'),
+    ('tolya', 'Anatoliy'),
+    ('kostya', 'Konstantin'),
+    ('nastya', 'Anastasiya'),
+    ('galya', 'Galina'),
+    ('lyuba', 'Lyubov'),
+    ('alyosha', 'Aleksey'),
+    ('seryozha', 'Sergey'),
+    ('andryusha', 'Andrey'),
+    ('ksyusha', 'Kseniya'),
+    ('sonya', 'Sofya'),
+    ('yulya', 'Yuliya')
+ON CONFLICT (diminutive) DO NOTHING;
+
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS resolved_name VARCHAR(255) NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE persons
+    DROP COLUMN IF EXISTS nationality_source,
+    DROP COLUMN IF EXISTS age_source;
+
+DROP TABLE IF EXISTS enrichment_log;
\ No newline at end of file
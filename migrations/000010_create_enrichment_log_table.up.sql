@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS enrichment_log (
+    id BIGSERIAL PRIMARY KEY,
+    person_id INT NOT NULL REFERENCES persons(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    request_url TEXT NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    response_body TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_enrichment_log_person_id ON enrichment_log(person_id, created_at DESC);
+
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS age_source VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS nationality_source VARCHAR(20) NOT NULL DEFAULT '';
+
+UPDATE persons SET age_source = 'provider' WHERE age IS NOT NULL;
+UPDATE persons SET nationality_source = 'provider' WHERE nationality IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_persons_needs_review;
+
+ALTER TABLE persons
+    DROP COLUMN IF EXISTS review,
+    DROP COLUMN IF EXISTS needs_review;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS needs_review BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS review JSONB;
+
+CREATE INDEX idx_persons_needs_review ON persons(id) WHERE needs_review;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE persons
+    DROP COLUMN IF EXISTS age_confidence;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS age_confidence DOUBLE PRECISION NOT NULL DEFAULT 0;
\ No newline at end of file
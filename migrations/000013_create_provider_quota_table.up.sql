@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS provider_quota (
+    provider VARCHAR(50) NOT NULL,
+    day DATE NOT NULL,
+    used INT NOT NULL DEFAULT 0,
+    daily_limit INT NOT NULL DEFAULT 0,
+    remaining INT,
+    reset_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, day)
+);
\ No newline at end of file
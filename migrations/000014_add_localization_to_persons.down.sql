@@ -0,0 +1,3 @@
+ALTER TABLE persons
+    DROP COLUMN IF EXISTS localization_country,
+    DROP COLUMN IF EXISTS country_hint;
\ No newline at end of file
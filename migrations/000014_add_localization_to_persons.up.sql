@@ -0,0 +1,3 @@
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS country_hint VARCHAR(2) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS localization_country VARCHAR(2) NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE persons
+    DROP COLUMN IF EXISTS surname_countries,
+    DROP COLUMN IF EXISTS name_countries;
\ No newline at end of file
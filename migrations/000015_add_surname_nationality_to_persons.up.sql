@@ -0,0 +1,3 @@
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS name_countries JSONB,
+    ADD COLUMN IF NOT EXISTS surname_countries JSONB;
\ No newline at end of file
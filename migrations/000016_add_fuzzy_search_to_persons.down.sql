@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_persons_patronymic_phonetic;
+DROP INDEX IF EXISTS idx_persons_surname_phonetic;
+DROP INDEX IF EXISTS idx_persons_name_phonetic;
+
+DROP INDEX IF EXISTS idx_persons_patronymic_trgm;
+DROP INDEX IF EXISTS idx_persons_surname_trgm;
+DROP INDEX IF EXISTS idx_persons_name_trgm;
+
+ALTER TABLE persons
+    DROP COLUMN IF EXISTS patronymic_phonetic,
+    DROP COLUMN IF EXISTS surname_phonetic,
+    DROP COLUMN IF EXISTS name_phonetic;
+
+DROP EXTENSION IF EXISTS pg_trgm;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS name_phonetic VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS surname_phonetic VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS patronymic_phonetic VARCHAR(255);
+
+CREATE INDEX idx_persons_name_trgm ON persons USING GIN (name gin_trgm_ops);
+CREATE INDEX idx_persons_surname_trgm ON persons USING GIN (surname gin_trgm_ops);
+CREATE INDEX idx_persons_patronymic_trgm ON persons USING GIN (patronymic gin_trgm_ops);
+
+CREATE INDEX idx_persons_name_phonetic ON persons(name_phonetic);
+CREATE INDEX idx_persons_surname_phonetic ON persons(surname_phonetic);
+CREATE INDEX idx_persons_patronymic_phonetic ON persons(patronymic_phonetic);
\ No newline at end of file
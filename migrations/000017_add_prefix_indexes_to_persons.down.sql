@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_persons_patronymic_prefix;
+DROP INDEX IF EXISTS idx_persons_surname_prefix;
+DROP INDEX IF EXISTS idx_persons_name_prefix;
\ No newline at end of file
@@ -0,0 +1,3 @@
+CREATE INDEX idx_persons_name_prefix ON persons (lower(name) text_pattern_ops, name);
+CREATE INDEX idx_persons_surname_prefix ON persons (lower(surname) text_pattern_ops, surname);
+CREATE INDEX idx_persons_patronymic_prefix ON persons (lower(patronymic) text_pattern_ops, patronymic);
\ No newline at end of file
@@ -0,0 +1,41 @@
+DELETE FROM name_diminutives WHERE diminutive IN (
+    'dima',
+    'mitya',
+    'lyosha',
+    'lesha',
+    'alesha',
+    'serezha',
+    'misha',
+    'masha',
+    'natasha',
+    'lena',
+    'ira',
+    'sveta',
+    'vova',
+    'volodya',
+    'pasha',
+    'vitya',
+    'nadya',
+    'anya',
+    'dasha',
+    'gosha',
+    'zhora',
+    'grisha',
+    'styopa',
+    'stepa',
+    'tyoma',
+    'tema',
+    'vasya',
+    'roma',
+    'maks',
+    'kiryusha',
+    'liza',
+    'vika'
+);
+
+INSERT INTO name_diminutives (diminutive, canonical) VALUES
+    ('саша', 'Александр'),
+    ('шура', 'Александр'),
+    ('женя', 'Евгений'),
+    ('слава', 'Вячеслав')
+ON CONFLICT (diminutive) DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,36 @@
+DELETE FROM name_diminutives WHERE diminutive IN ('саша', 'шура', 'женя', 'слава');
+
+INSERT INTO name_diminutives (diminutive, canonical) VALUES
+    ('dima', 'Dmitriy'),
+    ('mitya', 'Dmitriy'),
+    ('lyosha', 'Aleksey'),
+    ('lesha', 'Aleksey'),
+    ('alesha', 'Aleksey'),
+    ('serezha', 'Sergey'),
+    ('misha', 'Mikhail'),
+    ('masha', 'Mariya'),
+    ('natasha', 'Natalya'),
+    ('lena', 'Elena'),
+    ('ira', 'Irina'),
+    ('sveta', 'Svetlana'),
+    ('vova', 'Vladimir'),
+    ('volodya', 'Vladimir'),
+    ('pasha', 'Pavel'),
+    ('vitya', 'Viktor'),
+    ('nadya', 'Nadezhda'),
+    ('anya', 'Anna'),
+    ('dasha', 'Darya'),
+    ('gosha', 'Georgiy'),
+    ('zhora', 'Georgiy'),
+    ('grisha', 'Grigoriy'),
+    ('styopa', 'Stepan'),
+    ('stepa', 'Stepan'),
+    ('tyoma', 'Artem'),
+    ('tema', 'Artem'),
+    ('vasya', 'Vasiliy'),
+    ('roma', 'Roman'),
+    ('maks', 'Maksim'),
+    ('kiryusha', 'Kirill'),
+    ('liza', 'Elizaveta'),
+    ('vika', 'Viktoriya')
+ON CONFLICT (diminutive) DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE INDEX idx_persons_name_prefix ON persons (lower(name) text_pattern_ops, name);
+CREATE INDEX idx_persons_surname_prefix ON persons (lower(surname) text_pattern_ops, surname);
+CREATE INDEX idx_persons_patronymic_prefix ON persons (lower(patronymic) text_pattern_ops, patronymic);
+
+DROP TRIGGER IF EXISTS persons_name_suggestions ON persons;
+DROP FUNCTION IF EXISTS track_name_suggestions();
+DROP FUNCTION IF EXISTS adjust_name_suggestion(TEXT, TEXT, INTEGER);
+DROP TABLE IF EXISTS name_suggestions;
\ No newline at end of file
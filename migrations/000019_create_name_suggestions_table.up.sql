@@ -0,0 +1,71 @@
+CREATE TABLE IF NOT EXISTS name_suggestions (
+    field VARCHAR(20) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    count INTEGER NOT NULL,
+    PRIMARY KEY (field, key)
+);
+
+CREATE INDEX idx_name_suggestions_prefix ON name_suggestions (field, key text_pattern_ops);
+
+CREATE OR REPLACE FUNCTION adjust_name_suggestion(suggestion_field TEXT, suggestion_value TEXT, delta INTEGER) RETURNS void AS $$
+BEGIN
+    IF suggestion_value IS NULL OR suggestion_value = '' THEN
+        RETURN;
+    END IF;
+
+    INSERT INTO name_suggestions (field, key, value, count)
+    VALUES (suggestion_field, lower(suggestion_value), suggestion_value, delta)
+    ON CONFLICT (field, key) DO UPDATE SET count = name_suggestions.count + EXCLUDED.count;
+
+    DELETE FROM name_suggestions
+    WHERE field = suggestion_field AND key = lower(suggestion_value) AND count <= 0;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION track_name_suggestions() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' OR (TG_OP = 'UPDATE' AND OLD.name IS DISTINCT FROM NEW.name) THEN
+        PERFORM adjust_name_suggestion('name', OLD.name, -1);
+    END IF;
+    IF TG_OP = 'DELETE' OR (TG_OP = 'UPDATE' AND OLD.surname IS DISTINCT FROM NEW.surname) THEN
+        PERFORM adjust_name_suggestion('surname', OLD.surname, -1);
+    END IF;
+    IF TG_OP = 'DELETE' OR (TG_OP = 'UPDATE' AND OLD.patronymic IS DISTINCT FROM NEW.patronymic) THEN
+        PERFORM adjust_name_suggestion('patronymic', OLD.patronymic, -1);
+    END IF;
+
+    IF TG_OP = 'INSERT' OR (TG_OP = 'UPDATE' AND OLD.name IS DISTINCT FROM NEW.name) THEN
+        PERFORM adjust_name_suggestion('name', NEW.name, 1);
+    END IF;
+    IF TG_OP = 'INSERT' OR (TG_OP = 'UPDATE' AND OLD.surname IS DISTINCT FROM NEW.surname) THEN
+        PERFORM adjust_name_suggestion('surname', NEW.surname, 1);
+    END IF;
+    IF TG_OP = 'INSERT' OR (TG_OP = 'UPDATE' AND OLD.patronymic IS DISTINCT FROM NEW.patronymic) THEN
+        PERFORM adjust_name_suggestion('patronymic', NEW.patronymic, 1);
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER persons_name_suggestions
+    AFTER INSERT OR DELETE OR UPDATE OF name, surname, patronymic ON persons
+    FOR EACH ROW EXECUTE FUNCTION track_name_suggestions();
+
+INSERT INTO name_suggestions (field, key, value, count)
+SELECT field, lower(value), min(value), COUNT(*)
+FROM (
+    SELECT 'name' AS field, name AS value FROM persons
+    UNION ALL
+    SELECT 'surname', surname FROM persons
+    UNION ALL
+    SELECT 'patronymic', patronymic FROM persons
+) names
+WHERE value IS NOT NULL AND value <> ''
+GROUP BY field, lower(value)
+ON CONFLICT (field, key) DO NOTHING;
+
+DROP INDEX IF EXISTS idx_persons_name_prefix;
+DROP INDEX IF EXISTS idx_persons_surname_prefix;
+DROP INDEX IF EXISTS idx_persons_patronymic_prefix;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_persons_canonical_version;
+
+ALTER TABLE persons
+    DROP COLUMN IF EXISTS canonical_version;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS canonical_version INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_persons_canonical_version ON persons(canonical_version);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_persons_refresh_attempted_at;
+
+ALTER TABLE persons
+    DROP COLUMN IF EXISTS refresh_attempted_at;
\ No newline at end of file
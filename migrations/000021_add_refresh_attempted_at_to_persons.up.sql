@@ -0,0 +1,4 @@
+ALTER TABLE persons
+    ADD COLUMN IF NOT EXISTS refresh_attempted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_persons_refresh_attempted_at ON persons(refresh_attempted_at);